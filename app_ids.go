@@ -0,0 +1,93 @@
+package oxr
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"sync"
+	"time"
+)
+
+// defaultExhaustedFor is how long an App ID is avoided when the end of its billing period could not be determined.
+const defaultExhaustedFor = 24 * time.Hour
+
+// keyPool holds the credentials available to a Client. The same App ID is used until it is restricted, at which point
+// the next is used until the restricted App ID's billing period resets.
+type keyPool struct {
+	mu             sync.Mutex
+	providers      []CredentialsProvider
+	current        int
+	exhaustedUntil []time.Time
+}
+
+func newKeyPool(providers []CredentialsProvider) *keyPool {
+	if len(providers) == 0 {
+		providers = []CredentialsProvider{StaticCredentials("")}
+	}
+
+	return &keyPool{
+		providers:      providers,
+		exhaustedUntil: make([]time.Time, len(providers)),
+	}
+}
+
+// acquire returns the credentials to use, skipping any which are exhausted or have already been tried.
+func (p *keyPool) acquire(now time.Time, tried map[int]bool) (int, CredentialsProvider, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for i := 0; i < len(p.providers); i++ {
+		index := (p.current + i) % len(p.providers)
+		if tried[index] || now.Before(p.exhaustedUntil[index]) {
+			continue
+		}
+
+		p.current = index
+
+		return index, p.providers[index], true
+	}
+
+	return 0, nil, false
+}
+
+// exhaust avoids the App ID at index until the given time.
+func (p *keyPool) exhaust(index int, until time.Time) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.exhaustedUntil[index] = until
+}
+
+func (p *keyPool) size() int {
+	return len(p.providers)
+}
+
+// shouldRotate reports whether the error means another App ID should be tried.
+func shouldRotate(err error) bool {
+	return errors.Is(err, ErrAccessRestricted)
+}
+
+// resetAt determines when the billing period of the App ID resets using the Usage endpoint, which does not count
+// against the quota.
+func (c Client) resetAt(ctx context.Context, index int, appID string) time.Time {
+	now := time.Now()
+
+	v := url.Values{}
+	v.Add("prettyprint", "false")
+
+	var usage UsageResponse
+	_, err := c.fetch(ctx, call{endpoint: EndpointUsage, path: "usage.json", query: v}, index, appID, &usage)
+	if err != nil {
+		return now.Add(defaultExhaustedFor)
+	}
+
+	return billingReset(now, usage.Data.Usage.DaysRemaining)
+}
+
+// billingReset returns when the billing period resets given the days remaining reported by the Usage endpoint.
+func billingReset(now time.Time, daysRemaining int) time.Time {
+	now = now.UTC()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+
+	return today.AddDate(0, 0, daysRemaining+1)
+}
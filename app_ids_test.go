@@ -0,0 +1,111 @@
+package oxr_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jamieaitken/oxr"
+)
+
+func TestWithAppIDs(t *testing.T) {
+	tests := []struct {
+		name             string
+		givenResults     []mockResult
+		givenCalls       int
+		expectedAppIDs   []string
+		expectedErr      error
+		expectedKeyIndex int
+	}{
+		{
+			name:             "given first app id works, expect it used for every request",
+			givenResults:     []mockResult{{StatusCode: http.StatusOK, Body: successfulLatest()}},
+			givenCalls:       2,
+			expectedAppIDs:   []string{"first", "first"},
+			expectedKeyIndex: 0,
+		},
+		{
+			name: "given quota exceeded, expect next app id used and first avoided",
+			givenResults: []mockResult{
+				{StatusCode: http.StatusTooManyRequests, Body: errorPayload(http.StatusTooManyRequests, "access_restricted", "quota exceeded")},
+				{StatusCode: http.StatusOK, Body: successfulUsage()},
+				{StatusCode: http.StatusOK, Body: successfulLatest()},
+			},
+			givenCalls:       2,
+			expectedAppIDs:   []string{"first", "first", "second", "second"},
+			expectedKeyIndex: 1,
+		},
+		{
+			name: "given access restricted, expect next app id used",
+			givenResults: []mockResult{
+				{StatusCode: http.StatusForbidden, Body: errorPayload(http.StatusForbidden, "access_restricted", "suspended")},
+				{StatusCode: http.StatusInternalServerError},
+				{StatusCode: http.StatusOK, Body: successfulLatest()},
+			},
+			givenCalls:       1,
+			expectedAppIDs:   []string{"first", "first", "second"},
+			expectedKeyIndex: 1,
+		},
+		{
+			name: "given every app id restricted, expect last error then no further requests",
+			givenResults: []mockResult{
+				{StatusCode: http.StatusTooManyRequests, Body: errorPayload(http.StatusTooManyRequests, "access_restricted", "quota exceeded")},
+				{StatusCode: http.StatusOK, Body: successfulUsage()},
+				{StatusCode: http.StatusTooManyRequests, Body: errorPayload(http.StatusTooManyRequests, "access_restricted", "quota exceeded")},
+				{StatusCode: http.StatusOK, Body: successfulUsage()},
+			},
+			givenCalls:       2,
+			expectedAppIDs:   []string{"first", "first", "second", "second"},
+			expectedErr:      oxr.ErrAppIDsExhausted,
+			expectedKeyIndex: 1,
+		},
+		{
+			name: "given other error, expect no rotation",
+			givenResults: []mockResult{
+				{StatusCode: http.StatusBadRequest, Body: errorPayload(http.StatusBadRequest, "invalid_base", "invalid base")},
+			},
+			givenCalls:       1,
+			expectedAppIDs:   []string{"first"},
+			expectedErr:      oxr.ErrInvalidBase,
+			expectedKeyIndex: 0,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			doer := &sequenceDoer{GivenResults: test.givenResults}
+			observer := &recordingObserver{}
+
+			c := oxr.New(oxr.WithAppIDs("first", "second"), oxr.WithDoer(doer), oxr.WithObserver(observer))
+
+			var err error
+			for i := 0; i < test.givenCalls; i++ {
+				_, err = c.Latest(context.Background())
+			}
+
+			if !errors.Is(err, test.expectedErr) {
+				t.Fatalf("expected %v, got %v", test.expectedErr, err)
+			}
+
+			var actualAppIDs []string
+			for _, u := range doer.SpyURLs {
+				parsed, parseErr := url.Parse(u)
+				if parseErr != nil {
+					t.Fatal(parseErr)
+				}
+
+				actualAppIDs = append(actualAppIDs, parsed.Query().Get("app_id"))
+			}
+
+			if !cmp.Equal(actualAppIDs, test.expectedAppIDs) {
+				t.Fatal(cmp.Diff(actualAppIDs, test.expectedAppIDs))
+			}
+
+			if !cmp.Equal(observer.end.KeyIndex, test.expectedKeyIndex) {
+				t.Fatal(cmp.Diff(observer.end.KeyIndex, test.expectedKeyIndex))
+			}
+		})
+	}
+}
@@ -0,0 +1,212 @@
+package oxr
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+const (
+	auditFilePrefix = "audit-"
+	auditFileSuffix = ".jsonl"
+)
+
+var ErrDigestMismatch = errors.New("audit record does not match its digest")
+
+// AuditRecord is the exact payload of a successful response, as received from OXR.
+type AuditRecord struct {
+	Endpoint Endpoint `json:"endpoint"`
+	// URL is the URL of the request with the App ID redacted.
+	URL       string    `json:"url"`
+	FetchedAt time.Time `json:"fetched_at"`
+	// SHA256 is the hex encoded SHA-256 digest of Body.
+	SHA256 string `json:"sha256"`
+	Body   []byte `json:"body"`
+}
+
+// AuditSink archives the payload of every successful response sent by OXR. Responses served from a cache or
+// revalidated with 304 Not Modified are not recorded again, as their payload was recorded when first received.
+type AuditSink interface {
+	Record(ctx context.Context, record AuditRecord) error
+}
+
+// newAuditRecord returns the record of a payload, including its digest.
+func newAuditRecord(endpoint Endpoint, redactedURL string, fetchedAt time.Time, body []byte) AuditRecord {
+	digest := sha256.Sum256(body)
+
+	return AuditRecord{
+		Endpoint:  endpoint,
+		URL:       redactedURL,
+		FetchedAt: fetchedAt.UTC(),
+		SHA256:    hex.EncodeToString(digest[:]),
+		Body:      body,
+	}
+}
+
+// AuditDigestError is returned by VerifyAuditLog for a record whose body does not match its digest.
+type AuditDigestError struct {
+	Path string
+	Line int
+}
+
+// Error implements the error interface for AuditDigestError.
+func (e *AuditDigestError) Error() string {
+	return fmt.Sprintf("%s:%d: %v", e.Path, e.Line, ErrDigestMismatch)
+}
+
+// Is allows an AuditDigestError to be compared against ErrDigestMismatch using errors.Is.
+func (e *AuditDigestError) Is(target error) bool {
+	return target == ErrDigestMismatch
+}
+
+// FileAuditSink is an AuditSink which appends each record as a line of JSON to a file per UTC day, named after the day
+// the response was fetched, for example audit-2022-03-16.jsonl. Files are only ever appended to, and each record is
+// synced to disk before the call returns.
+type FileAuditSink struct {
+	dir string
+
+	mu   sync.Mutex
+	day  string
+	file *os.File
+}
+
+// NewFileAuditSink instantiates a FileAuditSink, creating the given directory if it does not exist.
+func NewFileAuditSink(dir string) (*FileAuditSink, error) {
+	err := os.MkdirAll(dir, 0o755)
+	if err != nil {
+		return nil, err
+	}
+
+	return &FileAuditSink{dir: dir}, nil
+}
+
+// Record implements AuditSink for FileAuditSink.
+func (f *FileAuditSink) Record(_ context.Context, record AuditRecord) error {
+	b, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	day := record.FetchedAt.UTC().Format(timeFormat)
+	if f.file == nil || f.day != day {
+		err = f.rotate(day)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Writing the record in a single call keeps lines whole should several processes share the directory.
+	_, err = f.file.Write(append(b, '\n'))
+	if err != nil {
+		return err
+	}
+
+	return f.file.Sync()
+}
+
+// Close closes the file currently being appended to.
+func (f *FileAuditSink) Close() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.file == nil {
+		return nil
+	}
+
+	err := f.file.Close()
+	f.file, f.day = nil, ""
+
+	return err
+}
+
+// rotate switches to the file of the given day.
+func (f *FileAuditSink) rotate(day string) error {
+	if f.file != nil {
+		err := f.file.Close()
+		f.file, f.day = nil, ""
+		if err != nil {
+			return err
+		}
+	}
+
+	path := filepath.Join(f.dir, auditFilePrefix+day+auditFileSuffix)
+
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o644)
+	if err != nil {
+		return err
+	}
+
+	f.file, f.day = file, day
+
+	return nil
+}
+
+// VerifyAuditLog re-computes the digest of every record written to dir by a FileAuditSink, returning how many were
+// verified. Verification stops at the first record which cannot be read, or whose body does not match its digest, in
+// which case an *AuditDigestError is returned.
+func VerifyAuditLog(dir string) (int, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, auditFilePrefix+"*"+auditFileSuffix))
+	if err != nil {
+		return 0, err
+	}
+
+	sort.Strings(paths)
+
+	var verified int
+	for _, path := range paths {
+		n, err := verifyAuditFile(path)
+		verified += n
+		if err != nil {
+			return verified, err
+		}
+	}
+
+	return verified, nil
+}
+
+func verifyAuditFile(path string) (int, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return 0, err
+	}
+	defer file.Close()
+
+	r := bufio.NewReader(file)
+
+	var verified int
+	for line := 1; ; line++ {
+		b, err := r.ReadBytes('\n')
+		if errors.Is(err, io.EOF) && len(b) == 0 {
+			return verified, nil
+		}
+		if err != nil && !errors.Is(err, io.EOF) {
+			return verified, err
+		}
+
+		var record AuditRecord
+		err = json.Unmarshal(bytes.TrimSpace(b), &record)
+		if err != nil {
+			return verified, fmt.Errorf("%s:%d: %w", path, line, err)
+		}
+
+		if newAuditRecord(record.Endpoint, record.URL, record.FetchedAt, record.Body).SHA256 != record.SHA256 {
+			return verified, &AuditDigestError{Path: path, Line: line}
+		}
+
+		verified++
+	}
+}
@@ -0,0 +1,223 @@
+package oxr_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/jamieaitken/oxr"
+)
+
+func TestWithAuditSink(t *testing.T) {
+	digest := sha256.Sum256([]byte(successfulLatest()))
+
+	tests := []struct {
+		name         string
+		givenResults []mockResult
+		givenCalls   int
+		expected     []oxr.AuditRecord
+	}{
+		{
+			name:         "given successful response, expect payload recorded",
+			givenResults: []mockResult{{StatusCode: http.StatusOK, Body: successfulLatest()}},
+			givenCalls:   1,
+			expected: []oxr.AuditRecord{
+				{
+					Endpoint: oxr.EndpointLatest,
+					URL:      "https://openexchangerates.org/api/latest.json?app_id=REDACTED&prettyprint=false&show_alternative=false",
+					SHA256:   hex.EncodeToString(digest[:]),
+					Body:     []byte(successfulLatest()),
+				},
+			},
+		},
+		{
+			name: "given not modified response, expect payload recorded once",
+			givenResults: []mockResult{
+				{StatusCode: http.StatusOK, Header: http.Header{"Etag": []string{`"abc"`}}, Body: successfulLatest()},
+				{StatusCode: http.StatusNotModified},
+			},
+			givenCalls: 2,
+			expected: []oxr.AuditRecord{
+				{
+					Endpoint: oxr.EndpointLatest,
+					URL:      "https://openexchangerates.org/api/latest.json?app_id=REDACTED&prettyprint=false&show_alternative=false",
+					SHA256:   hex.EncodeToString(digest[:]),
+					Body:     []byte(successfulLatest()),
+				},
+			},
+		},
+		{
+			name:         "given unsuccessful response, expect nothing recorded",
+			givenResults: []mockResult{{StatusCode: http.StatusUnauthorized, Body: errorPayload(http.StatusUnauthorized, "invalid_app_id", "Invalid App ID provided.")}},
+			givenCalls:   1,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			sink := &recordingSink{}
+			c := oxr.New(
+				oxr.WithAppID("test"),
+				oxr.WithDoer(&sequenceDoer{GivenResults: test.givenResults}),
+				oxr.WithAuditSink(sink),
+			)
+
+			for i := 0; i < test.givenCalls; i++ {
+				_, _ = c.Latest(context.Background())
+			}
+
+			if !cmp.Equal(sink.records, test.expected, cmpopts.IgnoreFields(oxr.AuditRecord{}, "FetchedAt")) {
+				t.Fatal(cmp.Diff(sink.records, test.expected, cmpopts.IgnoreFields(oxr.AuditRecord{}, "FetchedAt")))
+			}
+		})
+	}
+}
+
+func TestWithAuditSink_Fail(t *testing.T) {
+	sinkErr := errors.New("disk full")
+	c := oxr.New(
+		oxr.WithAppID("test"),
+		oxr.WithDoer(&sequenceDoer{GivenResults: []mockResult{{StatusCode: http.StatusOK, Body: successfulLatest()}}}),
+		oxr.WithAuditSink(&recordingSink{err: sinkErr}),
+	)
+
+	_, err := c.Latest(context.Background())
+	if !errors.Is(err, sinkErr) {
+		t.Fatalf("expected %v, got %v", sinkErr, err)
+	}
+}
+
+func TestFileAuditSink(t *testing.T) {
+	dir := t.TempDir()
+
+	sink, err := oxr.NewFileAuditSink(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	day := time.Date(2022, 3, 16, 23, 59, 0, 0, time.UTC)
+	records := []oxr.AuditRecord{
+		auditRecord(oxr.EndpointLatest, day, successfulLatest()),
+		auditRecord(oxr.EndpointCurrencies, day, successfulCurrencies()),
+		auditRecord(oxr.EndpointLatest, day.Add(time.Minute), successfulLatest()),
+	}
+	for _, record := range records {
+		err = sink.Record(context.Background(), record)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	err = sink.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	files, err := filepath.Glob(filepath.Join(dir, "*"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedFiles := []string{filepath.Join(dir, "audit-2022-03-16.jsonl"), filepath.Join(dir, "audit-2022-03-17.jsonl")}
+	if !cmp.Equal(files, expectedFiles) {
+		t.Fatal(cmp.Diff(files, expectedFiles))
+	}
+
+	verified, err := oxr.VerifyAuditLog(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !cmp.Equal(verified, len(records)) {
+		t.Fatal(cmp.Diff(verified, len(records)))
+	}
+}
+
+func TestVerifyAuditLog_Tampered(t *testing.T) {
+	dir := t.TempDir()
+
+	sink, err := oxr.NewFileAuditSink(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	day := time.Date(2022, 3, 16, 0, 0, 0, 0, time.UTC)
+	for _, body := range []string{successfulLatest(), successfulCurrencies()} {
+		err = sink.Record(context.Background(), auditRecord(oxr.EndpointLatest, day, body))
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	err = sink.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	path := filepath.Join(dir, "audit-2022-03-16.jsonl")
+
+	b, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	lines := strings.SplitN(string(b), "\n", 2)
+	tampered := auditRecord(oxr.EndpointLatest, day, successfulCurrencies())
+	writeFile(t, path, lines[0]+"\n"+strings.Replace(lines[1], tampered.SHA256, strings.Repeat("0", 64), 1))
+
+	verified, err := oxr.VerifyAuditLog(dir)
+
+	var digestErr *oxr.AuditDigestError
+	if !errors.As(err, &digestErr) || !errors.Is(err, oxr.ErrDigestMismatch) {
+		t.Fatalf("expected %v, got %v", oxr.ErrDigestMismatch, err)
+	}
+
+	expected := &oxr.AuditDigestError{Path: path, Line: 2}
+	if !cmp.Equal(digestErr, expected) {
+		t.Fatal(cmp.Diff(digestErr, expected))
+	}
+
+	if !cmp.Equal(verified, 1) {
+		t.Fatal(cmp.Diff(verified, 1))
+	}
+}
+
+func auditRecord(endpoint oxr.Endpoint, fetchedAt time.Time, body string) oxr.AuditRecord {
+	digest := sha256.Sum256([]byte(body))
+
+	return oxr.AuditRecord{
+		Endpoint:  endpoint,
+		URL:       "https://openexchangerates.org/api/" + string(endpoint) + ".json?app_id=REDACTED",
+		FetchedAt: fetchedAt,
+		SHA256:    hex.EncodeToString(digest[:]),
+		Body:      []byte(body),
+	}
+}
+
+type recordingSink struct {
+	mu      sync.Mutex
+	records []oxr.AuditRecord
+	err     error
+}
+
+func (r *recordingSink) Record(_ context.Context, record oxr.AuditRecord) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.err != nil {
+		return r.err
+	}
+
+	r.records = append(r.records, record)
+
+	return nil
+}
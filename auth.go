@@ -0,0 +1,70 @@
+package oxr
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// AuthMode decides how the App ID is sent to OXR.
+type AuthMode int
+
+// Available auth modes.
+const (
+	// AuthQuery sends the App ID as the app_id query parameter.
+	AuthQuery AuthMode = iota
+	// AuthHeader sends the App ID as an Authorization header, keeping it out of URLs which may be logged by proxies.
+	AuthHeader
+)
+
+// authHeaderPrefix is the scheme OXR accepts in the Authorization header.
+const authHeaderPrefix = "Token "
+
+// redactedError hides an App ID from the message of the error it wraps.
+type redactedError struct {
+	err   error
+	appID string
+}
+
+// Error implements the error interface for redactedError.
+func (r redactedError) Error() string {
+	return strings.ReplaceAll(r.err.Error(), r.appID, redacted)
+}
+
+// Unwrap allows the wrapped error to be inspected with errors.Is and errors.As.
+func (r redactedError) Unwrap() error {
+	return r.err
+}
+
+// redactError ensures the App ID does not appear in the error, including the URL of a *url.Error.
+func redactError(err error, appID string) error {
+	if err == nil || appID == "" {
+		return err
+	}
+
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) && err == error(urlErr) {
+		redactedURL := strings.ReplaceAll(urlErr.URL, appID, redacted)
+		if u, parseErr := url.Parse(urlErr.URL); parseErr == nil {
+			redactedURL = strings.ReplaceAll(redactURL(u), appID, redacted)
+		}
+
+		err = &url.Error{Op: urlErr.Op, URL: redactedURL, Err: urlErr.Err}
+	}
+
+	if strings.Contains(err.Error(), appID) {
+		return redactedError{err: err, appID: appID}
+	}
+
+	return err
+}
+
+// requestAppID returns the App ID sent with the request, whichever AuthMode was used.
+func requestAppID(r *http.Request) string {
+	if appID := r.URL.Query().Get("app_id"); appID != "" {
+		return appID
+	}
+
+	return strings.TrimPrefix(r.Header.Get("Authorization"), authHeaderPrefix)
+}
@@ -0,0 +1,130 @@
+package oxr_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jamieaitken/oxr"
+)
+
+func TestWithAuthMode(t *testing.T) {
+	tests := []struct {
+		name               string
+		givenMode          oxr.AuthMode
+		expectedQueryAppID string
+		expectedHeader     string
+	}{
+		{
+			name:               "given query mode, expect app id in query",
+			givenMode:          oxr.AuthQuery,
+			expectedQueryAppID: "secret",
+		},
+		{
+			name:           "given header mode, expect app id in authorization header",
+			givenMode:      oxr.AuthHeader,
+			expectedHeader: "Token secret",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var actual *http.Request
+
+			doer := oxr.DoerFunc(func(r *http.Request) (*http.Response, error) {
+				actual = r
+				return (&sequenceDoer{GivenResults: []mockResult{{StatusCode: http.StatusOK, Body: successfulLatest()}}}).Do(r)
+			})
+
+			c := oxr.New(oxr.WithAppID("secret"), oxr.WithDoer(doer), oxr.WithAuthMode(test.givenMode))
+
+			if _, err := c.Latest(context.Background()); err != nil {
+				t.Fatal(err)
+			}
+
+			if !cmp.Equal(actual.URL.Query().Get("app_id"), test.expectedQueryAppID) {
+				t.Fatal(cmp.Diff(actual.URL.Query().Get("app_id"), test.expectedQueryAppID))
+			}
+
+			if !cmp.Equal(actual.Header.Get("Authorization"), test.expectedHeader) {
+				t.Fatal(cmp.Diff(actual.Header.Get("Authorization"), test.expectedHeader))
+			}
+		})
+	}
+}
+
+func TestClient_RedactsAppID(t *testing.T) {
+	closed := httptest.NewServer(http.NotFoundHandler())
+	closed.Close()
+
+	tests := []struct {
+		name      string
+		givenMode oxr.AuthMode
+		givenDoer oxr.Doer
+	}{
+		{
+			name:      "given url error from http client, expect app id redacted",
+			givenMode: oxr.AuthQuery,
+			givenDoer: http.DefaultClient,
+		},
+		{
+			name:      "given header mode and url error from http client, expect app id redacted",
+			givenMode: oxr.AuthHeader,
+			givenDoer: http.DefaultClient,
+		},
+		{
+			name:      "given error containing app id, expect app id redacted",
+			givenMode: oxr.AuthHeader,
+			givenDoer: oxr.DoerFunc(func(r *http.Request) (*http.Response, error) {
+				return nil, errors.New("rejected " + r.Header.Get("Authorization"))
+			}),
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var buf bytes.Buffer
+
+			c := oxr.New(
+				oxr.WithAppID("secret"),
+				oxr.WithBaseURL(closed.URL),
+				oxr.WithAuthMode(test.givenMode),
+				oxr.WithDoer(test.givenDoer),
+				oxr.WithMiddleware(oxr.LoggingMiddleware(log.New(&buf, "", 0))),
+			)
+
+			_, err := c.Latest(context.Background())
+			if err == nil {
+				t.Fatal("expected error")
+			}
+
+			if strings.Contains(err.Error(), "secret") {
+				t.Fatalf("expected app id to be redacted from error: %v", err)
+			}
+
+			if strings.Contains(buf.String(), "secret") {
+				t.Fatalf("expected app id to be redacted from log: %s", buf.String())
+			}
+		})
+	}
+}
+
+func TestClient_RedactedErrorUnwraps(t *testing.T) {
+	c := oxr.New(oxr.WithAppID("secret"), oxr.WithBaseURL("http://127.0.0.1:0"), oxr.WithDoer(http.DefaultClient))
+
+	_, err := c.Latest(context.Background())
+
+	var urlErr *url.Error
+	if !errors.As(err, &urlErr) {
+		t.Fatalf("expected *url.Error, got %T", err)
+	}
+
+	if strings.Contains(urlErr.URL, "secret") {
+		t.Fatalf("expected app id to be redacted from url: %s", urlErr.URL)
+	}
+}
@@ -0,0 +1,226 @@
+package oxr
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// latestBatcher merges Latest calls for the same base made within a window of each other into a single request for
+// the union of their symbols.
+type latestBatcher struct {
+	window time.Duration
+
+	mu      sync.Mutex
+	batches map[string]*latestBatch
+}
+
+// latestBatch is a request which calls are waiting on.
+type latestBatch struct {
+	params  latestParams
+	symbols map[string]bool
+	// all reports whether a call asked for every symbol, in which case the request is made without symbols.
+	all bool
+
+	ctx     context.Context
+	cancel  context.CancelFunc
+	waiters int
+	done    chan struct{}
+	res     LatestRatesResponse
+	meta    ResponseMeta
+	err     error
+	// panicked reports whether sending the batch panicked, in which case panicValue is raised again in every waiting
+	// caller.
+	panicked   bool
+	panicValue interface{}
+}
+
+func newLatestBatcher(window time.Duration) *latestBatcher {
+	return &latestBatcher{
+		window:  window,
+		batches: make(map[string]*latestBatch),
+	}
+}
+
+// do adds the call to the pending batch for its base, starting one should there be none, and returns the rates of the
+// batch once it has been sent, keeping only the symbols the call asked for. As with concurrent identical calls, the
+// request is only cancelled once every waiting caller has given up, and a panic while sending it is raised again in
+// each caller still waiting.
+func (l *latestBatcher) do(ctx context.Context, c Client, r latestParams) (LatestRatesResponse, error) {
+	key := r.batchKey()
+
+	l.mu.Lock()
+
+	b, ok := l.batches[key]
+	if !ok {
+		batchCtx, cancel := context.WithCancel(detachedContext{parent: ctx})
+		b = &latestBatch{
+			params: latestParams{
+				baseCurrency:    r.baseCurrency,
+				showAlternative: r.showAlternative,
+				prettyPrint:     r.prettyPrint,
+			},
+			symbols: make(map[string]bool),
+			ctx:     batchCtx,
+			cancel:  cancel,
+			done:    make(chan struct{}),
+		}
+		l.batches[key] = b
+
+		time.AfterFunc(l.window, func() {
+			l.send(c, key, b)
+		})
+	}
+
+	b.add(r.destinationCurrencies)
+	b.waiters++
+
+	l.mu.Unlock()
+
+	select {
+	case <-b.done:
+		if b.panicked {
+			panic(b.panicValue)
+		}
+
+		if b.err != nil {
+			return LatestRatesResponse{}, b.err
+		}
+
+		r.meta.set(b.meta)
+
+		return onlySymbols(b.res, r.destinationCurrencies), nil
+	case <-ctx.Done():
+		if l.leave(key, b) {
+			// As with a shared call, the last caller waits for the cancelled batch to unwind.
+			<-b.done
+			if b.panicked {
+				panic(b.panicValue)
+			}
+		}
+
+		return LatestRatesResponse{}, ctx.Err()
+	}
+}
+
+// send requests the rates for every symbol of the batch once its window has elapsed.
+func (l *latestBatcher) send(c Client, key string, b *latestBatch) {
+	defer func() {
+		if p := recover(); p != nil {
+			b.panicked, b.panicValue = true, p
+		}
+
+		b.cancel()
+		close(b.done)
+	}()
+
+	l.mu.Lock()
+	if l.batches[key] == b {
+		delete(l.batches, key)
+	}
+
+	params := b.params
+	params.destinationCurrencies = b.destinations()
+	params.meta = &b.meta
+	l.mu.Unlock()
+
+	if err := b.ctx.Err(); err != nil {
+		b.err = err
+	} else {
+		b.res, b.err = c.requestLatest(b.ctx, params)
+	}
+}
+
+// leave removes a caller from the batch, cancelling it and reporting true once no callers remain. A batch abandoned
+// before it is sent is forgotten, so that later callers start a new one.
+func (l *latestBatcher) leave(key string, b *latestBatch) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	b.waiters--
+	if b.waiters > 0 {
+		return false
+	}
+
+	b.cancel()
+	if l.batches[key] == b {
+		delete(l.batches, key)
+	}
+
+	return true
+}
+
+// add includes the comma separated symbols in the batch, where none means every symbol.
+func (b *latestBatch) add(symbols string) {
+	if symbols == "" {
+		b.all = true
+		return
+	}
+
+	for _, symbol := range strings.Split(canonicalSymbols(symbols), ",") {
+		b.symbols[symbol] = true
+	}
+}
+
+// destinations returns the symbols to request for the batch, or none should every symbol be needed.
+func (b *latestBatch) destinations() string {
+	if b.all {
+		return ""
+	}
+
+	symbols := make([]string, 0, len(b.symbols))
+	for symbol := range b.symbols {
+		symbols = append(symbols, symbol)
+	}
+
+	sort.Strings(symbols)
+
+	return strings.Join(symbols, ",")
+}
+
+// batchKey canonicalises the parameters which calls must share to be merged into one request.
+func (p latestParams) batchKey() string {
+	base := strings.ToUpper(strings.TrimSpace(p.baseCurrency))
+	if base == "" {
+		base = "USD"
+	}
+
+	return fmt.Sprintf("base=%s&show_alternative=%t", base, p.showAlternative)
+}
+
+// onlySymbols returns a copy of the response keeping only the rates of the comma separated symbols, or every rate
+// should there be none.
+func onlySymbols(res LatestRatesResponse, symbols string) LatestRatesResponse {
+	keep := func(symbol string) bool { return true }
+	if symbols != "" {
+		wanted := make(map[string]bool)
+		for _, symbol := range strings.Split(canonicalSymbols(symbols), ",") {
+			wanted[symbol] = true
+		}
+
+		keep = func(symbol string) bool { return wanted[symbol] }
+	}
+
+	rates := make(map[string]float64, len(res.Rates))
+	for symbol, rate := range res.Rates {
+		if keep(symbol) {
+			rates[symbol] = rate
+		}
+	}
+	res.Rates = rates
+
+	if res.ExactRates != nil {
+		exact := make(map[string]Decimal, len(res.ExactRates))
+		for symbol, rate := range res.ExactRates {
+			if keep(symbol) {
+				exact[symbol] = rate
+			}
+		}
+		res.ExactRates = exact
+	}
+
+	return res
+}
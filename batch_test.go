@@ -0,0 +1,193 @@
+package oxr_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jamieaitken/oxr"
+)
+
+// batchWindow is long enough for every concurrent call of a test to join the same batch.
+const batchWindow = 50 * time.Millisecond
+
+func TestWithLatestBatching(t *testing.T) {
+	tests := []struct {
+		name            string
+		givenOpts       [][]oxr.LatestOption
+		expectedSymbols []string
+		expectedRates   []map[string]float64
+	}{
+		{
+			name: "given calls for same base, expect symbols merged into one request",
+			givenOpts: [][]oxr.LatestOption{
+				{oxr.LatestForDestinationCurrencies([]string{"GBP"})},
+				{oxr.LatestForDestinationCurrencies([]string{"EUR"})},
+				{oxr.LatestForDestinationCurrencies([]string{"KRW", "GBP"})},
+			},
+			expectedSymbols: []string{"EUR,GBP,KRW"},
+			expectedRates: []map[string]float64{
+				{"GBP": 0.764018},
+				{"EUR": 0.911},
+				{"GBP": 0.764018, "KRW": 1225.826828},
+			},
+		},
+		{
+			name: "given call for every symbol, expect request without symbols",
+			givenOpts: [][]oxr.LatestOption{
+				{oxr.LatestForDestinationCurrencies([]string{"GBP"})},
+				{},
+			},
+			expectedSymbols: []string{""},
+			expectedRates: []map[string]float64{
+				{"GBP": 0.764018},
+				{"EUR": 0.911, "GBP": 0.764018, "KRW": 1225.826828, "USD": 1},
+			},
+		},
+		{
+			name: "given calls for different bases, expect request per base",
+			givenOpts: [][]oxr.LatestOption{
+				{oxr.LatestForDestinationCurrencies([]string{"GBP"})},
+				{oxr.LatestForBaseCurrency(oxr.CurrencyUSD), oxr.LatestForDestinationCurrencies([]string{"EUR"})},
+				{oxr.LatestForBaseCurrency(oxr.CurrencyEUR), oxr.LatestForDestinationCurrencies([]string{"KRW"})},
+			},
+			expectedSymbols: []string{"EUR,GBP", "KRW"},
+			expectedRates: []map[string]float64{
+				{"GBP": 0.764018},
+				{"EUR": 0.911},
+				{"KRW": 1225.826828},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			doer := &sequenceDoer{GivenResults: []mockResult{{StatusCode: http.StatusOK, Body: batchedLatest()}}}
+			c := oxr.New(oxr.WithAppID("test"), oxr.WithDoer(doer), oxr.WithLatestBatching(batchWindow))
+
+			results := make([]oxr.LatestRatesResponse, len(test.givenOpts))
+			errs := make([]error, len(test.givenOpts))
+
+			var wg sync.WaitGroup
+			for i, opts := range test.givenOpts {
+				wg.Add(1)
+				go func(i int, opts []oxr.LatestOption) {
+					defer wg.Done()
+					results[i], errs[i] = c.Latest(context.Background(), opts...)
+				}(i, opts)
+			}
+			wg.Wait()
+
+			for i := range results {
+				if errs[i] != nil {
+					t.Fatal(errs[i])
+				}
+
+				if !cmp.Equal(results[i].Rates, test.expectedRates[i]) {
+					t.Fatal(cmp.Diff(results[i].Rates, test.expectedRates[i]))
+				}
+			}
+
+			var actualSymbols []string
+			for _, spy := range doer.SpyURLs {
+				u, err := url.Parse(spy)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				actualSymbols = append(actualSymbols, u.Query().Get("symbols"))
+			}
+			sort.Strings(actualSymbols)
+
+			if !cmp.Equal(actualSymbols, test.expectedSymbols) {
+				t.Fatal(cmp.Diff(actualSymbols, test.expectedSymbols))
+			}
+		})
+	}
+}
+
+func TestWithLatestBatching_Cancellation(t *testing.T) {
+	doer := &sequenceDoer{GivenResults: []mockResult{{StatusCode: http.StatusOK, Body: batchedLatest()}}}
+	c := oxr.New(oxr.WithAppID("test"), oxr.WithDoer(doer), oxr.WithLatestBatching(batchWindow))
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	canceledErr := make(chan error, 1)
+	go func() {
+		_, err := c.Latest(ctx, oxr.LatestForDestinationCurrencies([]string{"GBP"}))
+		canceledErr <- err
+	}()
+
+	waiting := make(chan oxr.LatestRatesResponse, 1)
+	go func() {
+		res, _ := c.Latest(context.Background(), oxr.LatestForDestinationCurrencies([]string{"EUR"}))
+		waiting <- res
+	}()
+
+	time.Sleep(batchWindow / 2)
+	cancel()
+
+	if err := <-canceledErr; !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected %v, got %v", context.Canceled, err)
+	}
+
+	expected := map[string]float64{"EUR": 0.911}
+	if actual := (<-waiting).Rates; !cmp.Equal(actual, expected) {
+		t.Fatal(cmp.Diff(actual, expected))
+	}
+
+	if !cmp.Equal(doer.Calls(), 1) {
+		t.Fatal(cmp.Diff(doer.Calls(), 1))
+	}
+}
+
+func TestWithLatestBatching_Panic(t *testing.T) {
+	doer := oxr.DoerFunc(func(r *http.Request) (*http.Response, error) {
+		panic("doer panicked")
+	})
+
+	c := oxr.New(oxr.WithAppID("test"), oxr.WithDoer(doer), oxr.WithLatestBatching(batchWindow))
+
+	recovered := make([]interface{}, 2)
+	symbols := []string{"GBP", "EUR"}
+
+	var wg sync.WaitGroup
+	for i := range recovered {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			defer func() {
+				recovered[i] = recover()
+			}()
+
+			_, _ = c.Latest(context.Background(), oxr.LatestForDestinationCurrencies([]string{symbols[i]}))
+		}(i)
+	}
+
+	wg.Wait()
+
+	expected := []interface{}{"doer panicked", "doer panicked"}
+	if !cmp.Equal(recovered, expected) {
+		t.Fatal(cmp.Diff(recovered, expected))
+	}
+}
+
+func batchedLatest() string {
+	return `{
+  "disclaimer": "Usage subject to terms: https://openexchangerates.org/terms",
+  "license": "https://openexchangerates.org/license",
+  "timestamp": 1647453600,
+  "base": "USD",
+  "rates": {
+    "EUR": 0.911,
+    "GBP": 0.764018,
+    "KRW": 1225.826828,
+    "USD": 1
+  }
+}`
+}
@@ -0,0 +1,212 @@
+package oxr
+
+import (
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// Cache stores encoded responses so that the Client can serve repeated requests without calling the Doer. A ttl of zero
+// means the entry never expires.
+type Cache interface {
+	Get(ctx context.Context, key string) ([]byte, bool, error)
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+}
+
+// MemoryCache is an in-memory Cache which evicts the least recently used entry once its capacity is reached.
+type MemoryCache struct {
+	capacity int
+
+	mu      sync.Mutex
+	order   *list.List
+	entries map[string]*list.Element
+	stats   CacheStats
+}
+
+type memoryEntry struct {
+	key     string
+	value   []byte
+	expires time.Time
+}
+
+// NewMemoryCache instantiates a MemoryCache holding at most capacity entries. A capacity of zero or less is unbounded.
+func NewMemoryCache(capacity int) *MemoryCache {
+	return &MemoryCache{
+		capacity: capacity,
+		order:    list.New(),
+		entries:  make(map[string]*list.Element),
+	}
+}
+
+// Get implements Cache for MemoryCache.
+func (m *MemoryCache) Get(_ context.Context, key string) ([]byte, bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	el, ok := m.entries[key]
+	if !ok {
+		m.stats.Misses++
+		return nil, false, nil
+	}
+
+	entry := el.Value.(*memoryEntry)
+	if !entry.expires.IsZero() && !time.Now().Before(entry.expires) {
+		m.remove(el)
+		m.stats.Misses++
+		return nil, false, nil
+	}
+
+	m.order.MoveToFront(el)
+	m.stats.Hits++
+
+	return append([]byte(nil), entry.value...), true, nil
+}
+
+// Set implements Cache for MemoryCache.
+func (m *MemoryCache) Set(_ context.Context, key string, value []byte, ttl time.Duration) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	entry := &memoryEntry{
+		key:   key,
+		value: append([]byte(nil), value...),
+	}
+	if ttl > 0 {
+		entry.expires = time.Now().Add(ttl)
+	}
+
+	if el, ok := m.entries[key]; ok {
+		el.Value = entry
+		m.order.MoveToFront(el)
+		return nil
+	}
+
+	m.entries[key] = m.order.PushFront(entry)
+
+	if m.capacity > 0 && m.order.Len() > m.capacity {
+		m.remove(m.order.Back())
+	}
+
+	return nil
+}
+
+// Stats returns the hit, miss and eviction counters of the cache.
+func (m *MemoryCache) Stats() CacheStats {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.stats
+}
+
+func (m *MemoryCache) remove(el *list.Element) {
+	m.order.Remove(el)
+	delete(m.entries, el.Value.(*memoryEntry).key)
+	m.stats.Evictions++
+}
+
+// FileCache is a Cache which stores each entry as a file within a directory. Files are written atomically, so the
+// directory can be shared by concurrent processes.
+type FileCache struct {
+	dir string
+}
+
+type fileEntry struct {
+	Key     string    `json:"key"`
+	Expires time.Time `json:"expires,omitempty"`
+	Value   []byte    `json:"value"`
+}
+
+// NewFileCache instantiates a FileCache, creating the given directory if it does not exist.
+func NewFileCache(dir string) (FileCache, error) {
+	err := os.MkdirAll(dir, 0o755)
+	if err != nil {
+		return FileCache{}, err
+	}
+
+	return FileCache{dir: dir}, nil
+}
+
+// Get implements Cache for FileCache.
+func (f FileCache) Get(_ context.Context, key string) ([]byte, bool, error) {
+	b, err := os.ReadFile(f.path(key))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+
+	var entry fileEntry
+	err = json.Unmarshal(b, &entry)
+	if err != nil || entry.Key != key {
+		return nil, false, nil
+	}
+
+	if !entry.Expires.IsZero() && !time.Now().Before(entry.Expires) {
+		_ = os.Remove(f.path(key))
+		return nil, false, nil
+	}
+
+	return entry.Value, true, nil
+}
+
+// Set implements Cache for FileCache.
+func (f FileCache) Set(_ context.Context, key string, value []byte, ttl time.Duration) error {
+	entry := fileEntry{
+		Key:   key,
+		Value: value,
+	}
+	if ttl > 0 {
+		entry.Expires = time.Now().Add(ttl)
+	}
+
+	b, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	return writeFileAtomic(f.path(key), b)
+}
+
+func (f FileCache) path(key string) string {
+	digest := sha256.Sum256([]byte(key))
+
+	return filepath.Join(f.dir, hex.EncodeToString(digest[:])+".json")
+}
+
+// writeFileAtomic writes to a temporary file in the same directory before renaming it into place, so readers never
+// observe a partially written file.
+func writeFileAtomic(path string, b []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+
+	_, err = tmp.Write(b)
+	if err == nil {
+		err = tmp.Sync()
+	}
+
+	closeErr := tmp.Close()
+	if err == nil {
+		err = closeErr
+	}
+
+	if err == nil {
+		err = os.Rename(tmp.Name(), path)
+	}
+
+	if err != nil {
+		_ = os.Remove(tmp.Name())
+	}
+
+	return err
+}
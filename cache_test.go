@@ -0,0 +1,223 @@
+package oxr_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jamieaitken/oxr"
+)
+
+func TestClient_Cache(t *testing.T) {
+	tests := []struct {
+		name          string
+		givenBody     string
+		givenCall     func(c oxr.Client) (interface{}, error)
+		expectedCalls int
+	}{
+		{
+			name:      "given latest within update interval, expect cached",
+			givenBody: latestAt(time.Now()),
+			givenCall: func(c oxr.Client) (interface{}, error) {
+				return c.Latest(context.Background(), oxr.LatestForDestinationCurrencies([]string{"GBP", "EUR"}))
+			},
+			expectedCalls: 1,
+		},
+		{
+			name:      "given stale latest, expect not cached",
+			givenBody: latestAt(time.Now().Add(-2 * time.Hour)),
+			givenCall: func(c oxr.Client) (interface{}, error) {
+				return c.Latest(context.Background())
+			},
+			expectedCalls: 2,
+		},
+		{
+			name:      "given historical for elapsed day, expect cached",
+			givenBody: successfulHistorical(),
+			givenCall: func(c oxr.Client) (interface{}, error) {
+				return c.Historical(context.Background(), oxr.HistoricalForDate(time.Date(2012, 7, 10, 0, 0, 0, 0, time.UTC)))
+			},
+			expectedCalls: 1,
+		},
+		{
+			name:      "given currencies, expect cached",
+			givenBody: successfulCurrencies(),
+			givenCall: func(c oxr.Client) (interface{}, error) {
+				return c.Currencies(context.Background())
+			},
+			expectedCalls: 1,
+		},
+		{
+			name:      "given time series for elapsed period, expect cached",
+			givenBody: successfulTimeSeries(),
+			givenCall: func(c oxr.Client) (interface{}, error) {
+				return c.TimeSeries(context.Background(),
+					oxr.TimeSeriesForStartDate(time.Date(2013, 1, 1, 0, 0, 0, 0, time.UTC)),
+					oxr.TimeSeriesForEndDate(time.Date(2013, 1, 31, 0, 0, 0, 0, time.UTC)),
+				)
+			},
+			expectedCalls: 1,
+		},
+		{
+			name:      "given stale conversion, expect not cached",
+			givenBody: successfulConversion(),
+			givenCall: func(c oxr.Client) (interface{}, error) {
+				return c.Convert(context.Background(), oxr.ConvertWithValue(100.12),
+					oxr.ConvertForBaseCurrency("GBP"), oxr.ConvertForDestinationCurrency("USD"))
+			},
+			expectedCalls: 2,
+		},
+		{
+			name:      "given elapsed ohlc period, expect cached",
+			givenBody: successfulOHLC(),
+			givenCall: func(c oxr.Client) (interface{}, error) {
+				return c.OpenHighLowClose(context.Background(), oxr.OHLCForPeriod(oxr.ThirtyMinute),
+					oxr.OHLCForStartTime(time.Date(2022, 3, 15, 13, 0, 0, 0, time.UTC)))
+			},
+			expectedCalls: 1,
+		},
+		{
+			name:      "given usage, expect never cached",
+			givenBody: successfulUsage(),
+			givenCall: func(c oxr.Client) (interface{}, error) {
+				return c.Usage(context.Background())
+			},
+			expectedCalls: 2,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			for name, cache := range caches(t) {
+				t.Run(name, func(t *testing.T) {
+					doer := &sequenceDoer{GivenResults: []mockResult{
+						{StatusCode: 200, Body: test.givenBody},
+					}}
+					c := oxr.New(oxr.WithAppID("test"), oxr.WithDoer(doer), oxr.WithCache(cache))
+
+					first, err := test.givenCall(c)
+					if err != nil {
+						t.Fatal(err)
+					}
+
+					second, err := test.givenCall(c)
+					if err != nil {
+						t.Fatal(err)
+					}
+
+					if !cmp.Equal(first, second) {
+						t.Fatal(cmp.Diff(first, second))
+					}
+
+					if !cmp.Equal(doer.Calls(), test.expectedCalls) {
+						t.Fatal(cmp.Diff(doer.Calls(), test.expectedCalls))
+					}
+				})
+			}
+		})
+	}
+}
+
+func TestMemoryCache(t *testing.T) {
+	ctx := context.Background()
+	cache := oxr.NewMemoryCache(2)
+
+	for i := 0; i < 3; i++ {
+		err := cache.Set(ctx, fmt.Sprint(i), []byte(fmt.Sprint(i)), 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if i == 1 {
+			// Touch the first entry so the second becomes least recently used.
+			if _, ok, _ := cache.Get(ctx, "0"); !ok {
+				t.Fatal("expected entry 0 to be present")
+			}
+		}
+	}
+
+	expectedPresence := map[string]bool{"0": true, "1": false, "2": true}
+	for key, expected := range expectedPresence {
+		_, ok, err := cache.Get(ctx, key)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !cmp.Equal(ok, expected) {
+			t.Fatalf("key %s: %s", key, cmp.Diff(ok, expected))
+		}
+	}
+
+	err := cache.Set(ctx, "expiring", []byte("value"), time.Millisecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	time.Sleep(5 * time.Millisecond)
+
+	if _, ok, _ := cache.Get(ctx, "expiring"); ok {
+		t.Fatal("expected expired entry to be absent")
+	}
+
+	expectedStats := oxr.CacheStats{Hits: 3, Misses: 2, Evictions: 3}
+	if !cmp.Equal(cache.Stats(), expectedStats) {
+		t.Fatal(cmp.Diff(cache.Stats(), expectedStats))
+	}
+}
+
+func TestFileCache(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+
+	writer, err := oxr.NewFileCache(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = writer.Set(ctx, "permanent", []byte(`{"base":"USD"}`), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = writer.Set(ctx, "expiring", []byte(`{"base":"GBP"}`), time.Millisecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	time.Sleep(5 * time.Millisecond)
+
+	reader, err := oxr.NewFileCache(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	actual, ok, err := reader.Get(ctx, "permanent")
+	if err != nil || !ok {
+		t.Fatalf("expected permanent entry, got %v, %v", ok, err)
+	}
+
+	if !cmp.Equal(string(actual), `{"base":"USD"}`) {
+		t.Fatal(cmp.Diff(string(actual), `{"base":"USD"}`))
+	}
+
+	if _, ok, _ = reader.Get(ctx, "expiring"); ok {
+		t.Fatal("expected expired entry to be absent")
+	}
+
+	if _, ok, _ = reader.Get(ctx, "missing"); ok {
+		t.Fatal("expected missing entry to be absent")
+	}
+}
+
+func caches(t *testing.T) map[string]oxr.Cache {
+	files, err := oxr.NewFileCache(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return map[string]oxr.Cache{
+		"memory": oxr.NewMemoryCache(10),
+		"file":   files,
+	}
+}
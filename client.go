@@ -3,20 +3,22 @@ package oxr
 import (
 	"context"
 	"encoding/json"
-	"errors"
 	"fmt"
+	"io"
 	"net/http"
+	"net/url"
+	"reflect"
 	"strconv"
+	"strings"
 	"time"
 )
 
 const (
 	timeFormat = "2006-01-02"
 	basePath   = "https://openexchangerates.org/api/"
-)
 
-var (
-	ErrBadResponse = errors.New("failed to receive successful response")
+	defaultUpdateInterval = time.Hour
+	currenciesFreshness   = 24 * time.Hour
 )
 
 // Doer sends a http.Request and returns a http.Response.
@@ -26,21 +28,49 @@ type Doer interface {
 
 // Client is responsible for all interactions between OXR.
 type Client struct {
-	appID   string
-	doer    Doer
-	baseURL string
+	credentials        []CredentialsProvider
+	keys               *keyPool
+	validators         *validators
+	flights            *flightGroup
+	batcher            *latestBatcher
+	doer               Doer
+	baseURL            string
+	latestCache        *LatestCache
+	historicalCache    *HistoricalDiskCache
+	cache              Cache
+	updateInterval     time.Duration
+	middleware         []Middleware
+	observer           Observer
+	audit              AuditSink
+	authMode           AuthMode
+	limiter            *QuotaLimiter
+	plan               *planFeatures
+	preflightChecks    bool
+	localRebase        bool
+	validateCurrencies bool
 }
 
 // New instantiates a Client.
 func New(opts ...ClientOption) Client {
 	c := Client{
-		baseURL: basePath,
+		baseURL:            basePath,
+		updateInterval:     defaultUpdateInterval,
+		observer:           NopObserver{},
+		validateCurrencies: true,
 	}
 
 	for _, opt := range opts {
 		opt(&c)
 	}
 
+	c.keys = newKeyPool(c.credentials)
+	c.validators = newValidators()
+	c.flights = newFlightGroup()
+	if c.preflightChecks || c.localRebase {
+		c.plan = &planFeatures{refresh: defaultPreflightRefresh}
+	}
+	c.doer = chain(c.doer, c.middleware)
+
 	return c
 }
 
@@ -53,14 +83,50 @@ func (c Client) Latest(ctx context.Context, opts ...LatestOption) (LatestRatesRe
 		opt(&r)
 	}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("%slatest.json", c.baseURL), http.NoBody)
+	if c.validateCurrencies && r.currencyErr != nil {
+		return LatestRatesResponse{}, r.currencyErr
+	}
+
+	if c.localRebase && r.baseCurrency != "" && !strings.EqualFold(r.baseCurrency, "USD") &&
+		!c.plan.supports(ctx, c, FeatureBase) {
+		return c.latestRebased(ctx, r)
+	}
+
+	return c.latest(ctx, r)
+}
+
+// latest retrieves the latest exchange rates for the given parameters.
+func (c Client) latest(ctx context.Context, r latestParams) (LatestRatesResponse, error) {
+	if c.latestCache != nil {
+		if res, ok := c.latestCache.get(r.cacheKey()); ok {
+			r.meta.set(ResponseMeta{Provenance: ProvenanceCache})
+			return res, nil
+		}
+	}
+
+	var (
+		res LatestRatesResponse
+		err error
+	)
+	if c.batcher != nil {
+		res, err = c.batcher.do(ctx, c, r)
+	} else {
+		res, err = c.requestLatest(ctx, r)
+	}
 	if err != nil {
 		return LatestRatesResponse{}, err
 	}
 
-	v := req.URL.Query()
+	if c.latestCache != nil {
+		c.latestCache.set(r.cacheKey(), res)
+	}
+
+	return res, nil
+}
 
-	v.Add("app_id", c.appID)
+// requestLatest requests the latest exchange rates for the given parameters from OXR.
+func (c Client) requestLatest(ctx context.Context, r latestParams) (LatestRatesResponse, error) {
+	v := url.Values{}
 	v.Add("prettyprint", strconv.FormatBool(r.prettyPrint))
 	if r.baseCurrency != "" {
 		v.Add("base", r.baseCurrency)
@@ -70,20 +136,17 @@ func (c Client) Latest(ctx context.Context, opts ...LatestOption) (LatestRatesRe
 	}
 	v.Add("show_alternative", strconv.FormatBool(r.showAlternative))
 
-	req.URL.RawQuery = v.Encode()
-
-	res, err := c.doer.Do(req)
-	defer res.Body.Close()
-	if err != nil {
-		return LatestRatesResponse{}, err
-	}
-
-	if res.StatusCode != http.StatusOK {
-		return LatestRatesResponse{}, fmt.Errorf("status received: %v: %w", res.StatusCode, ErrBadResponse)
-	}
-
 	var resData LatestRatesResponse
-	err = json.NewDecoder(res.Body).Decode(&resData)
+	err := c.get(ctx, call{
+		endpoint:    EndpointLatest,
+		path:        "latest.json",
+		query:       v,
+		conditional: true,
+		meta:        r.meta,
+		freshness: func(now time.Time) (time.Duration, bool) {
+			return c.untilNextUpdate(resData.Timestamp, now)
+		},
+	}, &resData)
 	if err != nil {
 		return LatestRatesResponse{}, err
 	}
@@ -101,14 +164,18 @@ func (c Client) Historical(ctx context.Context, opts ...HistoricalOption) (Histo
 		opt(&r)
 	}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet,
-		fmt.Sprintf("%shistorical/%s.json", c.baseURL, r.date.Format(timeFormat)), http.NoBody)
-	if err != nil {
-		return HistoricalRatesResponse{}, err
+	if c.validateCurrencies && r.currencyErr != nil {
+		return HistoricalRatesResponse{}, r.currencyErr
 	}
 
-	v := req.URL.Query()
-	v.Add("app_id", c.appID)
+	if c.historicalCache != nil {
+		if res, ok := c.historicalCache.get(ctx, r); ok {
+			r.meta.set(ResponseMeta{Provenance: ProvenanceCache})
+			return res, nil
+		}
+	}
+
+	v := url.Values{}
 	v.Add("prettyprint", strconv.FormatBool(r.prettyPrint))
 	v.Add("show_alternative", strconv.FormatBool(r.showAlternative))
 	if r.baseCurrency != "" {
@@ -118,23 +185,27 @@ func (c Client) Historical(ctx context.Context, opts ...HistoricalOption) (Histo
 		v.Add("symbols", r.destinationCurrencies)
 	}
 
-	req.URL.RawQuery = v.Encode()
-
-	res, err := c.doer.Do(req)
-	defer res.Body.Close()
-
+	var resData HistoricalRatesResponse
+	err := c.get(ctx, call{
+		endpoint: EndpointHistorical,
+		path:     fmt.Sprintf("historical/%s.json", r.date.Format(timeFormat)),
+		query:    v,
+		meta:     r.meta,
+		freshness: func(now time.Time) (time.Duration, bool) {
+			if dayElapsed(r.date, now) {
+				return 0, true
+			}
+
+			return c.untilNextUpdate(resData.Timestamp, now)
+		},
+	}, &resData)
 	if err != nil {
 		return HistoricalRatesResponse{}, err
 	}
 
-	if res.StatusCode != http.StatusOK {
-		return HistoricalRatesResponse{}, fmt.Errorf("status received: %v: %w", res.StatusCode, ErrBadResponse)
-	}
-
-	var resData HistoricalRatesResponse
-	err = json.NewDecoder(res.Body).Decode(&resData)
-	if err != nil {
-		return HistoricalRatesResponse{}, err
+	if c.historicalCache != nil {
+		// Failing to persist the response should not fail a request which has otherwise succeeded.
+		_ = c.historicalCache.set(ctx, r, resData)
 	}
 
 	return resData, nil
@@ -150,33 +221,22 @@ func (c Client) Currencies(ctx context.Context, opts ...CurrenciesOption) (Curre
 		opt(&r)
 	}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet,
-		fmt.Sprintf("%scurrencies.json", c.baseURL), http.NoBody)
-	if err != nil {
-		return CurrenciesResponse{}, err
-	}
-
-	v := req.URL.Query()
-	v.Add("app_id", c.appID)
+	v := url.Values{}
 	v.Add("show_inactive", strconv.FormatBool(r.showInactive))
 	v.Add("prettyprint", strconv.FormatBool(r.prettyPrint))
 	v.Add("show_alternative", strconv.FormatBool(r.showAlternative))
 
-	req.URL.RawQuery = v.Encode()
-
-	res, err := c.doer.Do(req)
-	defer res.Body.Close()
-
-	if err != nil {
-		return CurrenciesResponse{}, err
-	}
-
-	if res.StatusCode != http.StatusOK {
-		return CurrenciesResponse{}, fmt.Errorf("status received: %v: %w", res.StatusCode, ErrBadResponse)
-	}
-
 	var resData CurrenciesResponse
-	err = json.NewDecoder(res.Body).Decode(&resData.Currencies)
+	err := c.get(ctx, call{
+		endpoint:    EndpointCurrencies,
+		path:        "currencies.json",
+		query:       v,
+		conditional: true,
+		meta:        r.meta,
+		freshness: func(now time.Time) (time.Duration, bool) {
+			return currenciesFreshness, true
+		},
+	}, &resData.Currencies)
 	if err != nil {
 		return CurrenciesResponse{}, err
 	}
@@ -194,14 +254,11 @@ func (c Client) TimeSeries(ctx context.Context, opts ...TimeSeriesOption) (TimeS
 		opt(&r)
 	}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet,
-		fmt.Sprintf("%stime-series.json", c.baseURL), http.NoBody)
-	if err != nil {
-		return TimeSeriesResponse{}, err
+	if c.validateCurrencies && r.currencyErr != nil {
+		return TimeSeriesResponse{}, r.currencyErr
 	}
 
-	v := req.URL.Query()
-	v.Add("app_id", c.appID)
+	v := url.Values{}
 	v.Add("prettyprint", strconv.FormatBool(r.prettyPrint))
 	v.Add("show_alternative", strconv.FormatBool(r.showAlternative))
 	v.Add("start", r.startDate.Format(timeFormat))
@@ -213,21 +270,20 @@ func (c Client) TimeSeries(ctx context.Context, opts ...TimeSeriesOption) (TimeS
 		v.Add("base", r.baseCurrency)
 	}
 
-	req.URL.RawQuery = v.Encode()
-
-	res, err := c.doer.Do(req)
-	defer res.Body.Close()
-
-	if err != nil {
-		return TimeSeriesResponse{}, err
-	}
-
-	if res.StatusCode != http.StatusOK {
-		return TimeSeriesResponse{}, fmt.Errorf("status received: %v: %w", res.StatusCode, ErrBadResponse)
-	}
-
 	var resData TimeSeriesResponse
-	err = json.NewDecoder(res.Body).Decode(&resData)
+	err := c.get(ctx, call{
+		endpoint: EndpointTimeSeries,
+		path:     "time-series.json",
+		query:    v,
+		meta:     r.meta,
+		freshness: func(now time.Time) (time.Duration, bool) {
+			if dayElapsed(r.endDate, now) {
+				return 0, true
+			}
+
+			return c.updateInterval, true
+		},
+	}, &resData)
 	if err != nil {
 		return TimeSeriesResponse{}, err
 	}
@@ -244,31 +300,27 @@ func (c Client) Convert(ctx context.Context, opts ...ConvertOption) (ConversionR
 		opt(&r)
 	}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet,
-		fmt.Sprintf("%sconvert/%v/%s/%s", c.baseURL, r.value, r.baseCurrency, r.destinationCurrency), http.NoBody)
-	if err != nil {
-		return ConversionResponse{}, err
+	if r.err != nil {
+		return ConversionResponse{}, r.err
 	}
 
-	v := req.URL.Query()
-	v.Add("app_id", c.appID)
-	v.Add("prettyprint", strconv.FormatBool(r.prettyPrint))
-
-	req.URL.RawQuery = v.Encode()
-
-	res, err := c.doer.Do(req)
-	defer res.Body.Close()
-
-	if err != nil {
-		return ConversionResponse{}, err
+	if c.validateCurrencies && r.currencyErr != nil {
+		return ConversionResponse{}, r.currencyErr
 	}
 
-	if res.StatusCode != http.StatusOK {
-		return ConversionResponse{}, fmt.Errorf("status received: %v: %w", res.StatusCode, ErrBadResponse)
-	}
+	v := url.Values{}
+	v.Add("prettyprint", strconv.FormatBool(r.prettyPrint))
 
 	var resData ConversionResponse
-	err = json.NewDecoder(res.Body).Decode(&resData)
+	err := c.get(ctx, call{
+		endpoint: EndpointConvert,
+		path:     fmt.Sprintf("convert/%s/%s/%s", r.value, r.baseCurrency, r.destinationCurrency),
+		query:    v,
+		meta:     r.meta,
+		freshness: func(now time.Time) (time.Duration, bool) {
+			return c.untilNextUpdate(resData.Meta.Timestamp, now)
+		},
+	}, &resData)
 	if err != nil {
 		return ConversionResponse{}, err
 	}
@@ -286,14 +338,11 @@ func (c Client) OpenHighLowClose(ctx context.Context, opts ...OHLCOption) (OHLCR
 		opt(&r)
 	}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet,
-		fmt.Sprintf("%sohlc.json", c.baseURL), http.NoBody)
-	if err != nil {
-		return OHLCResponse{}, err
+	if c.validateCurrencies && r.currencyErr != nil {
+		return OHLCResponse{}, r.currencyErr
 	}
 
-	v := req.URL.Query()
-	v.Add("app_id", c.appID)
+	v := url.Values{}
 	v.Add("prettyprint", strconv.FormatBool(r.prettyPrint))
 	v.Add("start_date", r.startTime.Format(time.RFC3339))
 	v.Add("period", r.period.String())
@@ -304,21 +353,20 @@ func (c Client) OpenHighLowClose(ctx context.Context, opts ...OHLCOption) (OHLCR
 		v.Add("base", r.baseCurrency)
 	}
 
-	req.URL.RawQuery = v.Encode()
-
-	res, err := c.doer.Do(req)
-	defer res.Body.Close()
-
-	if err != nil {
-		return OHLCResponse{}, err
-	}
-
-	if res.StatusCode != http.StatusOK {
-		return OHLCResponse{}, fmt.Errorf("status received: %v: %w", res.StatusCode, ErrBadResponse)
-	}
-
 	var resData OHLCResponse
-	err = json.NewDecoder(res.Body).Decode(&resData)
+	err := c.get(ctx, call{
+		endpoint: EndpointOHLC,
+		path:     "ohlc.json",
+		query:    v,
+		meta:     r.meta,
+		freshness: func(now time.Time) (time.Duration, bool) {
+			if !resData.EndTime.IsZero() && !now.Before(resData.EndTime) {
+				return 0, true
+			}
+
+			return c.updateInterval, true
+		},
+	}, &resData)
 	if err != nil {
 		return OHLCResponse{}, err
 	}
@@ -335,34 +383,318 @@ func (c Client) Usage(ctx context.Context, opts ...UsageOption) (UsageResponse,
 		opt(&r)
 	}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet,
-		fmt.Sprintf("%susage.json", c.baseURL), http.NoBody)
+	v := url.Values{}
+	v.Add("prettyprint", strconv.FormatBool(r.prettyPrint))
+
+	var resData UsageResponse
+	err := c.get(ctx, call{
+		endpoint: EndpointUsage,
+		path:     "usage.json",
+		query:    v,
+		meta:     r.meta,
+	}, &resData)
 	if err != nil {
 		return UsageResponse{}, err
 	}
 
-	v := req.URL.Query()
-	v.Add("app_id", c.appID)
-	v.Add("prettyprint", strconv.FormatBool(r.prettyPrint))
+	return resData, nil
+}
 
-	req.URL.RawQuery = v.Encode()
+// call describes a request to an OXR endpoint.
+type call struct {
+	endpoint Endpoint
+	path     string
+	query    url.Values
+	// freshness reports how long the decoded response may be cached for, where a zero duration never expires. Responses
+	// are not cached when freshness is nil or reports false.
+	freshness func(now time.Time) (time.Duration, bool)
+	// conditional reports whether the call is revalidated using the ETag and Last-Modified of its previous response.
+	conditional bool
+	// meta is populated with how the result of the call was obtained, should it be non-nil.
+	meta *ResponseMeta
+}
 
-	res, err := c.doer.Do(req)
-	defer res.Body.Close()
+// cacheKey canonicalises the call so that requests which result in the same payload share a key.
+func (cl call) cacheKey() string {
+	q := url.Values{}
+	for k, v := range cl.query {
+		q[k] = v
+	}
+
+	q.Del("prettyprint")
+	if symbols := q.Get("symbols"); symbols != "" {
+		q.Set("symbols", canonicalSymbols(symbols))
+	}
+	if base := q.Get("base"); base != "" {
+		q.Set("base", strings.ToUpper(base))
+	}
+
+	return fmt.Sprintf("oxr:%s:%s?%s", cl.endpoint, cl.path, q.Encode())
+}
+
+// get performs the call, decoding a successful response into v. When a Cache is configured it is consulted first, and
+// successful responses are stored according to the call's freshness. Concurrent calls with the same canonical key share
+// a single request, which the caller that started it does not decode again.
+func (c Client) get(ctx context.Context, cl call, v interface{}) error {
+	var key string
+	if c.cache != nil && cl.freshness != nil {
+		key = cl.cacheKey()
 
+		b, ok, err := c.cache.Get(ctx, key)
+		if err == nil && ok {
+			if json.Unmarshal(b, v) == nil {
+				cl.meta.set(ResponseMeta{Provenance: ProvenanceCache})
+				return nil
+			}
+
+			reset(v)
+		}
+	}
+
+	fn := func(ctx context.Context, meta *ResponseMeta) (interface{}, []byte, error) {
+		flightCall := cl
+		flightCall.meta = meta
+
+		// The response is decoded into a value of the flight's own, as callers may give up before it completes.
+		value := reflect.New(reflect.TypeOf(v).Elem()).Interface()
+		body, err := c.send(ctx, flightCall, value)
+
+		return value, body, err
+	}
+
+	res, shared, err := c.flights.do(ctx, cl.cacheKey(), fn)
+	if res.meta.Provenance != "" {
+		cl.meta.set(res.meta)
+	}
 	if err != nil {
-		return UsageResponse{}, err
+		return err
 	}
 
-	if res.StatusCode != http.StatusOK {
-		return UsageResponse{}, fmt.Errorf("status received: %v: %w", res.StatusCode, ErrBadResponse)
+	if shared {
+		// Callers which joined the flight decode the body themselves, so that they do not share the maps of a response.
+		err = json.Unmarshal(res.body, v)
+		if err != nil {
+			return err
+		}
+	} else {
+		reflect.ValueOf(v).Elem().Set(reflect.ValueOf(res.value).Elem())
 	}
 
-	var resData UsageResponse
-	err = json.NewDecoder(res.Body).Decode(&resData)
+	// The caller which started the flight is responsible for caching its response.
+	if key != "" && !shared {
+		if ttl, ok := cl.freshness(time.Now()); ok {
+			// Failing to cache the response should not fail a request which has otherwise succeeded.
+			_ = c.cache.Set(ctx, key, res.body, ttl)
+		}
+	}
+
+	return nil
+}
+
+// send performs the call once any preflight checks and quota limits have passed, decoding a successful response into v.
+func (c Client) send(ctx context.Context, cl call, v interface{}) ([]byte, error) {
+	if c.preflightChecks && cl.endpoint != EndpointUsage {
+		err := c.preflight(ctx, cl)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Usage does not count against the quota, and is used by the QuotaLimiter itself.
+	if c.limiter != nil && cl.endpoint != EndpointUsage {
+		err := c.limiter.wait(ctx, c)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return c.rotate(ctx, cl, v)
+}
+
+// rotate performs the call using the App ID currently given by the credentials. Should the App ID be restricted, for
+// example because its quota has been exceeded, it is avoided until its billing period resets and the call is retried
+// with the next App ID. Credentials which fail to give an App ID are skipped for the call in the same way.
+func (c Client) rotate(ctx context.Context, cl call, v interface{}) ([]byte, error) {
+	tried := make(map[int]bool)
+
+	var lastErr error
+	for {
+		index, provider, ok := c.keys.acquire(time.Now(), tried)
+		if !ok {
+			if lastErr != nil {
+				return nil, lastErr
+			}
+
+			return nil, ErrAppIDsExhausted
+		}
+
+		tried[index] = true
+
+		appID, err := provider.AppID(ctx)
+		if err != nil {
+			lastErr = fmt.Errorf("retrieving app id: %w", err)
+			continue
+		}
+
+		body, err := c.fetch(ctx, cl, index, appID, v)
+		if err != nil && c.keys.size() > 1 && shouldRotate(err) {
+			c.keys.exhaust(index, c.resetAt(ctx, index, appID))
+			lastErr = err
+
+			continue
+		}
+
+		return body, err
+	}
+}
+
+// fetch performs a single request for the call using the given App ID, decoding a successful response into v.
+func (c Client) fetch(ctx context.Context, cl call, index int, appID string, v interface{}) ([]byte, error) {
+	q := url.Values{}
+	for k, val := range cl.query {
+		q[k] = val
+	}
+	if c.authMode == AuthQuery {
+		q.Set("app_id", appID)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("%s%s", c.baseURL, cl.path), http.NoBody)
 	if err != nil {
-		return UsageResponse{}, err
+		return nil, redactError(err, appID)
 	}
 
-	return resData, nil
+	req.URL.RawQuery = q.Encode()
+	if c.authMode == AuthHeader {
+		req.Header.Set("Authorization", authHeaderPrefix+appID)
+	}
+
+	var (
+		previous    validator
+		revalidated bool
+	)
+	if cl.conditional {
+		previous, revalidated = c.validators.get(cl.cacheKey())
+		if revalidated {
+			previous.apply(req)
+		}
+	}
+
+	c.observer.OnRequestStart(ctx, cl.endpoint)
+	start := time.Now()
+
+	res, err := c.do(req, appID)
+	fetchedAt := time.Now()
+	if err == nil && res.statusCode == http.StatusNotModified && !revalidated {
+		err = newAPIError(res.statusCode, res.body)
+	}
+
+	duration := time.Since(start)
+
+	c.observer.OnRequestEnd(ctx, RequestEnd{
+		Endpoint:   cl.endpoint,
+		KeyIndex:   index,
+		StatusCode: res.statusCode,
+		Duration:   duration,
+		BytesRead:  int64(len(res.body)),
+		Err:        err,
+	})
+
+	provenance := ProvenanceNetwork
+	if err == nil && res.statusCode == http.StatusNotModified {
+		provenance = ProvenanceRevalidated
+	}
+
+	finalURL := req.URL
+	if res.url != nil {
+		finalURL = res.url
+	}
+	redactedURL := redactURL(finalURL)
+
+	cl.meta.set(ResponseMeta{
+		Provenance: provenance,
+		StatusCode: res.statusCode,
+		Header:     res.header,
+		URL:        redactedURL,
+		Duration:   duration,
+		BytesRead:  int64(len(res.body)),
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	if res.statusCode == http.StatusNotModified {
+		res.body = previous.body
+	}
+
+	err = json.Unmarshal(res.body, v)
+	if err != nil {
+		c.observer.OnDecodeFailure(ctx, cl.endpoint, err)
+		return nil, err
+	}
+
+	// Only payloads which decode are revalidated, so that a corrupt payload is never reused.
+	if cl.conditional && res.statusCode != http.StatusNotModified {
+		if val, ok := newValidator(res.header, res.body); ok {
+			c.validators.set(cl.cacheKey(), val)
+		}
+	}
+
+	if c.audit != nil && provenance == ProvenanceNetwork {
+		err = c.audit.Record(ctx, newAuditRecord(cl.endpoint, redactedURL, fetchedAt, res.body))
+		if err != nil {
+			return nil, fmt.Errorf("recording audit: %w", err)
+		}
+	}
+
+	return res.body, nil
+}
+
+// response is the outcome of a request sent by the Doer.
+type response struct {
+	statusCode int
+	header     http.Header
+	// url is the URL of the request which resulted in the response, after any redirects.
+	url  *url.URL
+	body []byte
+}
+
+// do sends the request using the Doer and reads the body of the response. Responses other than 200 OK or 304 Not
+// Modified are returned as an *APIError. The App ID is redacted from any other error.
+func (c Client) do(req *http.Request, appID string) (response, error) {
+	res, err := c.doer.Do(req)
+	if err != nil {
+		return response{}, redactError(err, appID)
+	}
+	defer res.Body.Close()
+
+	body, err := io.ReadAll(res.Body)
+	err = redactError(err, appID)
+	r := response{
+		statusCode: res.StatusCode,
+		header:     res.Header,
+		body:       body,
+	}
+	if res.Request != nil {
+		r.url = res.Request.URL
+	}
+
+	if res.StatusCode != http.StatusOK && res.StatusCode != http.StatusNotModified {
+		return r, newAPIError(res.StatusCode, body)
+	}
+
+	return r, err
+}
+
+// untilNextUpdate returns how long remains until rates published at the given timestamp are superseded.
+func (c Client) untilNextUpdate(timestamp int64, now time.Time) (time.Duration, bool) {
+	ttl := time.Unix(timestamp, 0).Add(c.updateInterval).Sub(now)
+
+	return ttl, ttl > 0
+}
+
+// reset sets the value pointed to by v to its zero value.
+func reset(v interface{}) {
+	rv := reflect.ValueOf(v).Elem()
+	rv.Set(reflect.Zero(rv.Type()))
 }
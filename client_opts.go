@@ -1,12 +1,37 @@
 package oxr
 
+import (
+	"strings"
+	"time"
+)
+
 // ClientOption allows a Client to be modified.
 type ClientOption func(*Client)
 
 // WithAppID sets the Open Exchange App ID to be used.
 func WithAppID(appID string) ClientOption {
 	return func(client *Client) {
-		client.appID = appID
+		client.credentials = []CredentialsProvider{StaticCredentials(appID)}
+	}
+}
+
+// WithCredentialsProvider sets the CredentialsProvider consulted for the App ID on every request. Give more than one to
+// fail over between them in the same way as WithAppIDs.
+func WithCredentialsProvider(providers ...CredentialsProvider) ClientOption {
+	return func(client *Client) {
+		client.credentials = append([]CredentialsProvider(nil), providers...)
+	}
+}
+
+// WithAppIDs sets a pool of Open Exchange App IDs to be used. The first is used until it is restricted, for example
+// because its quota has been exceeded, after which the next is used. Restricted App IDs are avoided until their billing
+// period resets. RequestEnd.KeyIndex reports which App ID served each request.
+func WithAppIDs(appIDs ...string) ClientOption {
+	return func(client *Client) {
+		client.credentials = make([]CredentialsProvider, len(appIDs))
+		for i, appID := range appIDs {
+			client.credentials[i] = StaticCredentials(appID)
+		}
 	}
 }
 
@@ -16,3 +41,118 @@ func WithDoer(doer Doer) ClientOption {
 		client.doer = doer
 	}
 }
+
+// WithLatestCache enables caching of Latest responses until newer rates are expected to be available.
+func WithLatestCache(cache *LatestCache) ClientOption {
+	return func(client *Client) {
+		client.latestCache = cache
+	}
+}
+
+// WithHistoricalCache enables persistent caching of Historical responses for fully elapsed days.
+func WithHistoricalCache(cache HistoricalDiskCache) ClientOption {
+	return func(client *Client) {
+		client.historicalCache = &cache
+	}
+}
+
+// WithCache sets the Cache consulted before every request. Each endpoint decides how long its responses remain fresh,
+// for example Latest responses are cached until the plan is next expected to update its rates.
+func WithCache(cache Cache) ClientOption {
+	return func(client *Client) {
+		client.cache = cache
+	}
+}
+
+// WithUpdateInterval sets how frequently the plan of the App ID updates its rates, which is used to decide how long
+// responses remain fresh. Defaults to one hour.
+func WithUpdateInterval(interval time.Duration) ClientOption {
+	return func(client *Client) {
+		client.updateInterval = interval
+	}
+}
+
+// WithBaseURL sets the URL requests are sent to, such as an oxrtest.Server. Defaults to the Open Exchange Rates API.
+func WithBaseURL(baseURL string) ClientOption {
+	return func(client *Client) {
+		if !strings.HasSuffix(baseURL, "/") {
+			baseURL += "/"
+		}
+
+		client.baseURL = baseURL
+	}
+}
+
+// WithMiddleware wraps the Doer with the given Middleware, in order, so the first Middleware is the first to see each
+// request. It may be used more than once.
+func WithMiddleware(middleware ...Middleware) ClientOption {
+	return func(client *Client) {
+		client.middleware = append(client.middleware, middleware...)
+	}
+}
+
+// WithObserver sets the Observer notified of every request sent to OXR.
+func WithObserver(observer Observer) ClientOption {
+	return func(client *Client) {
+		client.observer = observer
+	}
+}
+
+// WithAuditSink archives the exact payload of every successful response with the sink. Should the sink fail to record
+// a payload, the call fails too, as the payload could not be relied upon.
+func WithAuditSink(sink AuditSink) ClientOption {
+	return func(client *Client) {
+		client.audit = sink
+	}
+}
+
+// WithAuthMode sets how the App ID is sent to OXR. Defaults to AuthQuery.
+func WithAuthMode(mode AuthMode) ClientOption {
+	return func(client *Client) {
+		client.authMode = mode
+	}
+}
+
+// WithQuotaLimiter paces requests so that the quota lasts for the rest of the billing period. Responses served from a
+// cache do not count against the quota and are not limited.
+func WithQuotaLimiter(limiter *QuotaLimiter) ClientOption {
+	return func(client *Client) {
+		client.limiter = limiter
+	}
+}
+
+// WithPreflight loads the features of the plan from the Usage endpoint, refreshing them hourly, so that calls the plan
+// does not support fail with a *FeatureError without a request being sent.
+func WithPreflight() ClientOption {
+	return func(client *Client) {
+		client.preflightChecks = true
+	}
+}
+
+// WithLocalRebase derives Latest rates for other base currencies from those in US Dollars when the plan does not
+// support changing the base currency. Derived responses are marked as Derived.
+func WithLocalRebase() ClientOption {
+	return func(client *Client) {
+		client.localRebase = true
+	}
+}
+
+// WithCurrencyValidation sets whether currencies given to options are checked against the registry before a request is
+// sent, failing with ErrInvalidCurrency should they not be in it. Disable it to request codes which OXR serves but the
+// registry does not yet know. Defaults to true.
+func WithCurrencyValidation(enabled bool) ClientOption {
+	return func(client *Client) {
+		client.validateCurrencies = enabled
+	}
+}
+
+// WithLatestBatching holds Latest calls for the given window, merging the symbols of those for the same base into a
+// single request. Each call receives only the rates of the symbols it asked for. A window of zero disables batching.
+func WithLatestBatching(window time.Duration) ClientOption {
+	return func(client *Client) {
+		client.batcher = nil
+		if window > 0 {
+			client.batcher = newLatestBatcher(window)
+		}
+	}
+}
@@ -52,8 +52,46 @@ func TestClient_Convert_Success(t *testing.T) {
 				Meta: oxr.ConversionMeta{
 					Timestamp: 1449885661,
 					Rate:      0.76,
+					ExactRate: oxr.MustParseDecimal("0.76"),
 				},
-				Response: 76.0912,
+				Response:      76.0912,
+				ExactResponse: oxr.MustParseDecimal("76.0912"),
+			},
+		},
+		{
+			name: "given exact value, expect value sent unchanged",
+			givenDoer: &mockDoer{
+				GivenResponse: &http.Response{
+					Status:     http.StatusText(http.StatusOK),
+					StatusCode: http.StatusOK,
+					Body:       io.NopCloser(strings.NewReader(successfulConversion())),
+				},
+			},
+			givenClientOpts: []oxr.ClientOption{
+				oxr.WithAppID("test"),
+			},
+			givenConvertOpts: []oxr.ConvertOption{
+				oxr.ConvertWithExactValue(oxr.MustParseDecimal("100.120")),
+				oxr.ConvertForBaseCurrency("GBP"),
+				oxr.ConvertForDestinationCurrency("USD"),
+			},
+			expectedURL: "https://openexchangerates.org/api/convert/100.120/GBP/USD?app_id=test&prettyprint=false",
+			expectedResult: oxr.ConversionResponse{
+				Disclaimer: "https://openexchangerates.org/terms/",
+				License:    "https://openexchangerates.org/license/",
+				Request: oxr.ConversionRequest{
+					Query:  "/convert/100.12/GBP/USD",
+					Amount: 100.12,
+					From:   "GBP",
+					To:     "USD",
+				},
+				Meta: oxr.ConversionMeta{
+					Timestamp: 1449885661,
+					Rate:      0.76,
+					ExactRate: oxr.MustParseDecimal("0.76"),
+				},
+				Response:      76.0912,
+				ExactResponse: oxr.MustParseDecimal("76.0912"),
 			},
 		},
 	}
@@ -297,6 +335,10 @@ func TestClient_Historical_Success(t *testing.T) {
 					"GBP": 0.76,
 					"EUR": 0.93,
 				},
+				ExactRates: map[string]oxr.Decimal{
+					"GBP": oxr.MustParseDecimal("0.76"),
+					"EUR": oxr.MustParseDecimal("0.93"),
+				},
 			},
 		},
 	}
@@ -422,6 +464,11 @@ func TestClient_Latest_Success(t *testing.T) {
 					"KRW": 1225.826828,
 					"USD": 1,
 				},
+				ExactRates: map[string]oxr.Decimal{
+					"GBP": oxr.MustParseDecimal("0.764018"),
+					"KRW": oxr.MustParseDecimal("1225.826828"),
+					"USD": oxr.MustParseDecimal("1"),
+				},
 			},
 		},
 	}
@@ -560,6 +607,22 @@ func TestClient_OpenHighLowClose_Success(t *testing.T) {
 						Average: 0.765503,
 					},
 				},
+				ExactRates: map[string]oxr.OHLCExactRate{
+					"EUR": {
+						Open:    oxr.MustParseDecimal("0.872674"),
+						High:    oxr.MustParseDecimal("0.872674"),
+						Low:     oxr.MustParseDecimal("0.87203"),
+						Close:   oxr.MustParseDecimal("0.872251"),
+						Average: oxr.MustParseDecimal("0.872253"),
+					},
+					"GBP": {
+						Open:    oxr.MustParseDecimal("0.765284"),
+						High:    oxr.MustParseDecimal("0.7657"),
+						Low:     oxr.MustParseDecimal("0.7652"),
+						Close:   oxr.MustParseDecimal("0.765541"),
+						Average: oxr.MustParseDecimal("0.765503"),
+					},
+				},
 			},
 		},
 	}
@@ -696,6 +759,23 @@ func TestClient_TimeSeries_Success(t *testing.T) {
 						"HKD": 8.116954,
 					},
 				},
+				ExactRates: map[string]map[string]oxr.Decimal{
+					"2013-01-01": {
+						"BTC": oxr.MustParseDecimal("0.0778595876"),
+						"EUR": oxr.MustParseDecimal("0.785518"),
+						"HKD": oxr.MustParseDecimal("8.04136"),
+					},
+					"2013-01-02": {
+						"BTC": oxr.MustParseDecimal("0.0789400739"),
+						"EUR": oxr.MustParseDecimal("0.795034"),
+						"HKD": oxr.MustParseDecimal("8.138096"),
+					},
+					"2013-01-03": {
+						"BTC": oxr.MustParseDecimal("0.0785299961"),
+						"EUR": oxr.MustParseDecimal("0.80092"),
+						"HKD": oxr.MustParseDecimal("8.116954"),
+					},
+				},
 			},
 		},
 	}
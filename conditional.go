@@ -0,0 +1,74 @@
+package oxr
+
+import (
+	"net/http"
+	"sync"
+)
+
+// maxValidators bounds how many responses are kept for revalidation, so that polling many distinct requests cannot
+// grow the Client without limit.
+const maxValidators = 1024
+
+// validator holds the ETag and Last-Modified of a response alongside its body, so that a 304 Not Modified can be
+// answered with the payload previously received.
+type validator struct {
+	etag         string
+	lastModified string
+	body         []byte
+}
+
+// newValidator returns the validator of a response, reporting false should OXR have given neither an ETag nor a
+// Last-Modified.
+func newValidator(header http.Header, body []byte) (validator, bool) {
+	v := validator{
+		etag:         header.Get("ETag"),
+		lastModified: header.Get("Last-Modified"),
+		body:         body,
+	}
+
+	return v, v.etag != "" || v.lastModified != ""
+}
+
+// apply sets the conditional headers of the request, so OXR only sends the payload should it have changed.
+func (v validator) apply(req *http.Request) {
+	if v.etag != "" {
+		req.Header.Set("If-None-Match", v.etag)
+	}
+	if v.lastModified != "" {
+		req.Header.Set("If-Modified-Since", v.lastModified)
+	}
+}
+
+// validators stores the validator of each conditional call by its canonical key.
+type validators struct {
+	mu      sync.Mutex
+	entries map[string]validator
+}
+
+func newValidators() *validators {
+	return &validators{entries: make(map[string]validator)}
+}
+
+func (v *validators) get(key string) (validator, bool) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	val, ok := v.entries[key]
+
+	return val, ok
+}
+
+func (v *validators) set(key string, val validator) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	if _, ok := v.entries[key]; !ok && len(v.entries) >= maxValidators {
+		// Evicting an arbitrary entry only costs that call a full response the next time it is made.
+		for k := range v.entries {
+			delete(v.entries, k)
+			break
+		}
+	}
+
+	v.entries[key] = val
+}
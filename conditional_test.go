@@ -0,0 +1,141 @@
+package oxr_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jamieaitken/oxr"
+)
+
+func TestClient_ConditionalRequests(t *testing.T) {
+	tests := []struct {
+		name            string
+		givenResults    []mockResult
+		givenCall       func(c oxr.Client) (interface{}, error)
+		expectedHeaders []http.Header
+	}{
+		{
+			name: "given etag on latest, expect if-none-match sent and payload reused on 304",
+			givenResults: []mockResult{
+				{StatusCode: http.StatusOK, Header: http.Header{"Etag": []string{`"abc"`}}, Body: successfulLatest()},
+				{StatusCode: http.StatusNotModified},
+			},
+			givenCall: func(c oxr.Client) (interface{}, error) {
+				return c.Latest(context.Background(), oxr.LatestForBaseCurrency(oxr.CurrencyUSD))
+			},
+			expectedHeaders: []http.Header{
+				{},
+				{"If-None-Match": []string{`"abc"`}},
+			},
+		},
+		{
+			name: "given last modified on currencies, expect if-modified-since sent and payload reused on 304",
+			givenResults: []mockResult{
+				{
+					StatusCode: http.StatusOK,
+					Header:     http.Header{"Last-Modified": []string{"Wed, 16 Mar 2022 18:00:00 GMT"}},
+					Body:       successfulCurrencies(),
+				},
+				{StatusCode: http.StatusNotModified},
+			},
+			givenCall: func(c oxr.Client) (interface{}, error) {
+				return c.Currencies(context.Background())
+			},
+			expectedHeaders: []http.Header{
+				{},
+				{"If-Modified-Since": []string{"Wed, 16 Mar 2022 18:00:00 GMT"}},
+			},
+		},
+		{
+			name: "given changed payload, expect new payload and validator",
+			givenResults: []mockResult{
+				{StatusCode: http.StatusOK, Header: http.Header{"Etag": []string{`"abc"`}}, Body: successfulLatest()},
+				{StatusCode: http.StatusOK, Header: http.Header{"Etag": []string{`"def"`}}, Body: successfulLatest()},
+				{StatusCode: http.StatusNotModified},
+			},
+			givenCall: func(c oxr.Client) (interface{}, error) {
+				return c.Latest(context.Background())
+			},
+			expectedHeaders: []http.Header{
+				{},
+				{"If-None-Match": []string{`"abc"`}},
+				{"If-None-Match": []string{`"def"`}},
+			},
+		},
+		{
+			name: "given etag on historical, expect no conditional headers",
+			givenResults: []mockResult{
+				{StatusCode: http.StatusOK, Header: http.Header{"Etag": []string{`"abc"`}}, Body: successfulHistorical()},
+			},
+			givenCall: func(c oxr.Client) (interface{}, error) {
+				return c.Historical(context.Background())
+			},
+			expectedHeaders: []http.Header{
+				{},
+				{},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			doer := &sequenceDoer{GivenResults: test.givenResults}
+			c := oxr.New(oxr.WithAppID("test"), oxr.WithDoer(doer))
+
+			var first interface{}
+			for i := range test.expectedHeaders {
+				actual, err := test.givenCall(c)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				if i == 0 {
+					first = actual
+				}
+
+				if !cmp.Equal(actual, first) {
+					t.Fatal(cmp.Diff(actual, first))
+				}
+			}
+
+			if !cmp.Equal(doer.SpyHeaders, test.expectedHeaders) {
+				t.Fatal(cmp.Diff(doer.SpyHeaders, test.expectedHeaders))
+			}
+		})
+	}
+}
+
+func TestClient_ConditionalRequests_UnexpectedNotModified(t *testing.T) {
+	doer := &sequenceDoer{GivenResults: []mockResult{{StatusCode: http.StatusNotModified}}}
+	c := oxr.New(oxr.WithAppID("test"), oxr.WithDoer(doer))
+
+	_, err := c.Latest(context.Background())
+	if !errors.Is(err, oxr.ErrBadResponse) {
+		t.Fatalf("expected %v, got %v", oxr.ErrBadResponse, err)
+	}
+}
+
+func TestClient_ConditionalRequests_CorruptPayload(t *testing.T) {
+	doer := &sequenceDoer{GivenResults: []mockResult{
+		{StatusCode: http.StatusOK, Header: http.Header{"Etag": []string{`"abc"`}}, Body: `{"rates": {"GBP": 0.7`},
+		{StatusCode: http.StatusNotModified},
+	}}
+	c := oxr.New(oxr.WithAppID("test"), oxr.WithDoer(doer))
+
+	_, err := c.Latest(context.Background())
+	if err == nil {
+		t.Fatal("expected corrupt payload to fail")
+	}
+
+	_, err = c.Latest(context.Background())
+	if !errors.Is(err, oxr.ErrBadResponse) {
+		t.Fatalf("expected %v, got %v", oxr.ErrBadResponse, err)
+	}
+
+	expectedHeaders := []http.Header{{}, {}}
+	if !cmp.Equal(doer.SpyHeaders, expectedHeaders) {
+		t.Fatal(cmp.Diff(doer.SpyHeaders, expectedHeaders))
+	}
+}
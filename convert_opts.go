@@ -1,10 +1,17 @@
 package oxr
 
 type convertParams struct {
-	value               float64
+	value               Decimal
 	baseCurrency        string
 	destinationCurrency string
 	prettyPrint         bool
+	meta                *ResponseMeta
+
+	// currencyErr is the first currency given which is not in the registry, returned before any request is sent unless
+	// currency validation is disabled.
+	currencyErr error
+	// err is the first other invalid option given, which is always returned before any request is sent.
+	err error
 }
 
 // ConvertOption allows the client to specify values for a conversion request.
@@ -13,19 +20,29 @@ type ConvertOption func(*convertParams)
 // ConvertForBaseCurrency sets the base currency for a conversion.
 func ConvertForBaseCurrency(currency string) ConvertOption {
 	return func(p *convertParams) {
-		p.baseCurrency = currency
+		code, err := validCurrency(currency)
+		p.baseCurrency, p.currencyErr = code, firstErr(p.currencyErr, err)
 	}
 }
 
 // ConvertForDestinationCurrency sets the destination currency for a conversion.
 func ConvertForDestinationCurrency(currency string) ConvertOption {
 	return func(p *convertParams) {
-		p.destinationCurrency = currency
+		code, err := validCurrency(currency)
+		p.destinationCurrency, p.currencyErr = code, firstErr(p.currencyErr, err)
 	}
 }
 
-// ConvertWithValue sets the value to be converted.
+// ConvertWithValue sets the value to be converted. Use ConvertWithExactValue to convert an exact amount.
 func ConvertWithValue(value float64) ConvertOption {
+	return func(p *convertParams) {
+		d, err := decimalFromFloat(value)
+		p.value, p.err = d, firstErr(p.err, err)
+	}
+}
+
+// ConvertWithExactValue sets the value to be converted exactly, such as the Amount of a Money.
+func ConvertWithExactValue(value Decimal) ConvertOption {
 	return func(p *convertParams) {
 		p.value = value
 	}
@@ -37,3 +54,10 @@ func ConvertWithPrettyPrint(active bool) ConvertOption {
 		p.prettyPrint = active
 	}
 }
+
+// ConvertWithMeta populates meta with how the result of the conversion request was obtained.
+func ConvertWithMeta(meta *ResponseMeta) ConvertOption {
+	return func(p *convertParams) {
+		p.meta = meta
+	}
+}
@@ -0,0 +1,200 @@
+package oxr
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Converter converts values between currencies locally using a snapshot of rates, such as a LatestRatesResponse,
+// without sending a request to OXR. Currencies other than the snapshot's base are converted using the cross rate
+// through the base. Money is rounded to the minor units of its currency, unless a Rounding is given.
+type Converter struct {
+	disclaimer string
+	license    string
+	timestamp  int64
+	base       string
+	rates      map[string]float64
+	exact      map[string]Decimal
+	rounding   Rounding
+	// rounded reports whether a Rounding was given, in which case Convert rounds its responses too.
+	rounded bool
+}
+
+// ConverterOption allows a Converter to be modified.
+type ConverterOption func(*Converter)
+
+// ConverterWithRounding sets how converted amounts are rounded, and has Convert round its responses as well. Money
+// defaults to half up to the minor units of its currency, while Convert does not round by default.
+func ConverterWithRounding(rounding Rounding) ConverterOption {
+	return func(c *Converter) {
+		c.rounding = rounding
+		c.rounded = true
+	}
+}
+
+// NewLatestConverter instantiates a Converter using the rates of a Latest response.
+func NewLatestConverter(res LatestRatesResponse, opts ...ConverterOption) Converter {
+	c := newConverter(res.Disclaimer, res.License, res.Timestamp, res.Base, res.Rates, res.ExactRates)
+
+	for _, opt := range opts {
+		opt(&c)
+	}
+
+	return c
+}
+
+// NewHistoricalConverter instantiates a Converter using the rates of a Historical response.
+func NewHistoricalConverter(res HistoricalRatesResponse, opts ...ConverterOption) Converter {
+	c := newConverter(res.Disclaimer, res.License, res.Timestamp, res.Base, res.Rates, res.ExactRates)
+
+	for _, opt := range opts {
+		opt(&c)
+	}
+
+	return c
+}
+
+func newConverter(
+	disclaimer, license string, timestamp int64, base string, rates map[string]float64, exact map[string]Decimal,
+) Converter {
+	base = strings.ToUpper(base)
+	if base == "" {
+		base = "USD"
+	}
+
+	snapshot := make(map[string]float64, len(rates)+1)
+	for currency, rate := range rates {
+		snapshot[strings.ToUpper(currency)] = rate
+	}
+	snapshot[base] = 1
+
+	exactSnapshot := make(map[string]Decimal, len(rates)+1)
+	for currency, rate := range exactRates(exact, rates) {
+		exactSnapshot[strings.ToUpper(currency)] = rate
+	}
+	exactSnapshot[base] = NewDecimal(1, 0)
+
+	return Converter{
+		disclaimer: disclaimer,
+		license:    license,
+		timestamp:  timestamp,
+		base:       base,
+		rates:      snapshot,
+		exact:      exactSnapshot,
+	}
+}
+
+// Convert converts the value between currencies in the same manner as Client.Convert. Meta is populated with the rate
+// used and the timestamp of the snapshot. As with OXR, the response is the value multiplied by Meta.ExactRate and is
+// not rounded, unless ConverterWithRounding was given. Currencies are looked up in the snapshot rather than the
+// registry, failing with ErrMissingRate should it not have them. The provenance given to ConvertWithMeta is always
+// ProvenanceDerived.
+func (c Converter) Convert(opts ...ConvertOption) (ConversionResponse, error) {
+	r := convertParams{}
+
+	for _, opt := range opts {
+		opt(&r)
+	}
+
+	if r.err != nil {
+		return ConversionResponse{}, r.err
+	}
+
+	from, to := strings.ToUpper(r.baseCurrency), strings.ToUpper(r.destinationCurrency)
+
+	rate, err := c.Rate(from, to)
+	if err != nil {
+		return ConversionResponse{}, err
+	}
+
+	exactRate, err := c.ExactRate(from, to, rebasePlaces, RoundHalfEven)
+	if err != nil {
+		return ConversionResponse{}, err
+	}
+
+	// The rate is calculated to many places so that it is exact where possible, which need not be shown.
+	exactRate = exactRate.trimmed()
+
+	converted := NewMoney(r.value.Mul(exactRate).trimmed(), to)
+	if c.rounded {
+		converted, err = c.ConvertMoney(NewMoney(r.value, from), to)
+		if err != nil {
+			return ConversionResponse{}, err
+		}
+	}
+
+	r.meta.set(ResponseMeta{Provenance: ProvenanceDerived})
+
+	return ConversionResponse{
+		Disclaimer: c.disclaimer,
+		License:    c.license,
+		Request: ConversionRequest{
+			Query:  fmt.Sprintf("/convert/%s/%s/%s", r.value, from, to),
+			Amount: r.value.Float64(),
+			From:   from,
+			To:     to,
+		},
+		Meta: ConversionMeta{
+			Timestamp: c.timestamp,
+			Rate:      rate,
+			ExactRate: exactRate,
+		},
+		Response:      converted.Amount.Float64(),
+		ExactResponse: converted.Amount,
+	}, nil
+}
+
+// Rate returns the rate at which one unit of the from currency converts into the to currency.
+func (c Converter) Rate(from, to string) (float64, error) {
+	from, to = strings.ToUpper(from), strings.ToUpper(to)
+
+	fromRate, ok := c.rates[from]
+	if !ok || fromRate <= 0 {
+		return 0, fmt.Errorf("converting from %s to %s: %s: %w", from, to, from, ErrMissingRate)
+	}
+
+	toRate, ok := c.rates[to]
+	if !ok {
+		return 0, fmt.Errorf("converting from %s to %s: %s: %w", from, to, to, ErrMissingRate)
+	}
+
+	return toRate / fromRate, nil
+}
+
+// ConvertMoney converts m into the to currency using the exact rates of the snapshot. The amount is multiplied by the
+// exact rate of the to currency and divided by that of its own currency, rounding the result only once.
+func (c Converter) ConvertMoney(m Money, to string) (Money, error) {
+	from, to := strings.ToUpper(m.Currency), strings.ToUpper(to)
+
+	fromRate, ok := c.exact[from]
+	if !ok || fromRate.Sign() <= 0 {
+		return Money{}, fmt.Errorf("converting from %s to %s: %s: %w", from, to, from, ErrMissingRate)
+	}
+
+	toRate, ok := c.exact[to]
+	if !ok {
+		return Money{}, fmt.Errorf("converting from %s to %s: %s: %w", from, to, to, ErrMissingRate)
+	}
+
+	r := c.rounding.For(to)
+
+	amount, err := m.Amount.Mul(toRate).Div(fromRate, r.Places, r.Policy)
+	if err != nil {
+		return Money{}, err
+	}
+
+	return NewMoney(amount, to), nil
+}
+
+// ExactRate returns the rate at which one unit of the from currency converts into the to currency, calculated from the
+// exact rates of the snapshot and rounded to the given number of decimal places according to the policy.
+func (c Converter) ExactRate(from, to string, places int32, policy RoundingPolicy) (Decimal, error) {
+	c.rounding = NewRounding(RoundingForCurrency(to, places, policy))
+
+	m, err := c.ConvertMoney(NewMoney(NewDecimal(1, 0), from), to)
+	if err != nil {
+		return Decimal{}, err
+	}
+
+	return m.Amount, nil
+}
@@ -0,0 +1,137 @@
+package oxr_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/jamieaitken/oxr"
+)
+
+func TestConverter_Convert(t *testing.T) {
+	snapshot := oxr.LatestRatesResponse{
+		Disclaimer: "Usage subject to terms: https://openexchangerates.org/terms",
+		License:    "https://openexchangerates.org/license",
+		Timestamp:  1647453600,
+		Base:       "USD",
+		Rates:      map[string]float64{"GBP": 0.8, "EUR": 0.9},
+	}
+
+	tests := []struct {
+		name        string
+		givenOpts   []oxr.ConvertOption
+		expected    oxr.ConversionResponse
+		expectedErr error
+	}{
+		{
+			name: "given base to currency, expect snapshot rate",
+			givenOpts: []oxr.ConvertOption{
+				oxr.ConvertWithValue(100),
+				oxr.ConvertForBaseCurrency("USD"),
+				oxr.ConvertForDestinationCurrency("GBP"),
+			},
+			expected: oxr.ConversionResponse{
+				Disclaimer:    "Usage subject to terms: https://openexchangerates.org/terms",
+				License:       "https://openexchangerates.org/license",
+				Request:       oxr.ConversionRequest{Query: "/convert/100/USD/GBP", Amount: 100, From: "USD", To: "GBP"},
+				Meta:          oxr.ConversionMeta{Timestamp: 1647453600, Rate: 0.8, ExactRate: oxr.MustParseDecimal("0.8")},
+				Response:      80,
+				ExactResponse: oxr.MustParseDecimal("80"),
+			},
+		},
+		{
+			name: "given currency to base, expect inverse rate",
+			givenOpts: []oxr.ConvertOption{
+				oxr.ConvertWithValue(100),
+				oxr.ConvertForBaseCurrency("gbp"),
+				oxr.ConvertForDestinationCurrency("usd"),
+			},
+			expected: oxr.ConversionResponse{
+				Disclaimer:    "Usage subject to terms: https://openexchangerates.org/terms",
+				License:       "https://openexchangerates.org/license",
+				Request:       oxr.ConversionRequest{Query: "/convert/100/GBP/USD", Amount: 100, From: "GBP", To: "USD"},
+				Meta:          oxr.ConversionMeta{Timestamp: 1647453600, Rate: 1.25, ExactRate: oxr.MustParseDecimal("1.25")},
+				Response:      125,
+				ExactResponse: oxr.MustParseDecimal("125"),
+			},
+		},
+		{
+			name: "given cross currencies, expect cross rate through base",
+			givenOpts: []oxr.ConvertOption{
+				oxr.ConvertWithValue(80),
+				oxr.ConvertForBaseCurrency("GBP"),
+				oxr.ConvertForDestinationCurrency("EUR"),
+			},
+			expected: oxr.ConversionResponse{
+				Disclaimer:    "Usage subject to terms: https://openexchangerates.org/terms",
+				License:       "https://openexchangerates.org/license",
+				Request:       oxr.ConversionRequest{Query: "/convert/80/GBP/EUR", Amount: 80, From: "GBP", To: "EUR"},
+				Meta:          oxr.ConversionMeta{Timestamp: 1647453600, Rate: 1.125, ExactRate: oxr.MustParseDecimal("1.125")},
+				Response:      90,
+				ExactResponse: oxr.MustParseDecimal("90"),
+			},
+		},
+		{
+			name: "given exact value, expect it converted exactly",
+			givenOpts: []oxr.ConvertOption{
+				oxr.ConvertWithExactValue(oxr.MustParseDecimal("0.1")),
+				oxr.ConvertForBaseCurrency("USD"),
+				oxr.ConvertForDestinationCurrency("GBP"),
+			},
+			expected: oxr.ConversionResponse{
+				Disclaimer:    "Usage subject to terms: https://openexchangerates.org/terms",
+				License:       "https://openexchangerates.org/license",
+				Request:       oxr.ConversionRequest{Query: "/convert/0.1/USD/GBP", Amount: 0.1, From: "USD", To: "GBP"},
+				Meta:          oxr.ConversionMeta{Timestamp: 1647453600, Rate: 0.8, ExactRate: oxr.MustParseDecimal("0.8")},
+				Response:      0.08,
+				ExactResponse: oxr.MustParseDecimal("0.08"),
+			},
+		},
+		{
+			name: "given currency missing from snapshot, expect error",
+			givenOpts: []oxr.ConvertOption{
+				oxr.ConvertWithValue(100),
+				oxr.ConvertForBaseCurrency("GBP"),
+				oxr.ConvertForDestinationCurrency("JPY"),
+			},
+			expectedErr: oxr.ErrMissingRate,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			actual, err := oxr.NewLatestConverter(snapshot).Convert(test.givenOpts...)
+			if !errors.Is(err, test.expectedErr) {
+				t.Fatalf("expected %v, got %v", test.expectedErr, err)
+			}
+
+			if !cmp.Equal(actual, test.expected, cmpopts.EquateApprox(0, 1e-12)) {
+				t.Fatal(cmp.Diff(actual, test.expected))
+			}
+
+			// Decimals compare equal regardless of scale, so their string forms are compared too.
+			actualStrings := []string{actual.Meta.ExactRate.String(), actual.ExactResponse.String()}
+			expectedStrings := []string{test.expected.Meta.ExactRate.String(), test.expected.ExactResponse.String()}
+			if !cmp.Equal(actualStrings, expectedStrings) {
+				t.Fatal(cmp.Diff(actualStrings, expectedStrings))
+			}
+		})
+	}
+}
+
+func TestNewHistoricalConverter(t *testing.T) {
+	c := oxr.NewHistoricalConverter(oxr.HistoricalRatesResponse{
+		Timestamp: 1646092800,
+		Base:      "GBP",
+		Rates:     map[string]float64{"USD": 1.25},
+	})
+
+	actual, err := c.Rate("USD", "GBP")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !cmp.Equal(actual, 0.8) {
+		t.Fatal(cmp.Diff(actual, 0.8))
+	}
+}
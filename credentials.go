@@ -0,0 +1,119 @@
+package oxr
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// defaultCredentialsInterval is how often FileCredentials checks its file for changes by default.
+const defaultCredentialsInterval = time.Second
+
+// ErrNoCredentials is returned when a CredentialsProvider has no App ID to give.
+var ErrNoCredentials = errors.New("no app id available")
+
+// CredentialsProvider supplies the App ID used for a request. It is consulted on every request, allowing the App ID to
+// change without creating a new Client.
+type CredentialsProvider interface {
+	AppID(ctx context.Context) (string, error)
+}
+
+// StaticCredentials is a CredentialsProvider which always gives the same App ID.
+type StaticCredentials string
+
+// AppID implements CredentialsProvider for StaticCredentials.
+func (s StaticCredentials) AppID(context.Context) (string, error) {
+	return string(s), nil
+}
+
+// EnvCredentials is a CredentialsProvider which reads the App ID from an environment variable on every request.
+type EnvCredentials string
+
+// AppID implements CredentialsProvider for EnvCredentials.
+func (e EnvCredentials) AppID(context.Context) (string, error) {
+	appID := strings.TrimSpace(os.Getenv(string(e)))
+	if appID == "" {
+		return "", fmt.Errorf("environment variable %s: %w", string(e), ErrNoCredentials)
+	}
+
+	return appID, nil
+}
+
+// FileCredentials is a CredentialsProvider which reads the App ID from a file, such as a secret mounted by an
+// orchestrator. The file is read again at most once per interval, so the App ID can be rotated without a restart.
+// Should the file become unreadable, the last App ID read is given.
+type FileCredentials struct {
+	path     string
+	interval time.Duration
+
+	mu        sync.Mutex
+	appID     string
+	checkedAt time.Time
+}
+
+// FileCredentialsOption allows FileCredentials to be modified.
+type FileCredentialsOption func(*FileCredentials)
+
+// FileCredentialsWithInterval sets how often the file is read. Defaults to one second.
+func FileCredentialsWithInterval(interval time.Duration) FileCredentialsOption {
+	return func(f *FileCredentials) {
+		f.interval = interval
+	}
+}
+
+// NewFileCredentials instantiates FileCredentials, reading the App ID from the file at path.
+func NewFileCredentials(path string, opts ...FileCredentialsOption) (*FileCredentials, error) {
+	f := &FileCredentials{
+		path:     path,
+		interval: defaultCredentialsInterval,
+	}
+
+	for _, opt := range opts {
+		opt(f)
+	}
+
+	err := f.reload(time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	return f, nil
+}
+
+// AppID implements CredentialsProvider for FileCredentials.
+func (f *FileCredentials) AppID(context.Context) (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	now := time.Now()
+	if now.Sub(f.checkedAt) >= f.interval {
+		// The previous App ID remains in use should the file be mid-rotation.
+		_ = f.reload(now)
+	}
+
+	return f.appID, nil
+}
+
+// reload reads the App ID from the file. Its contents are read every time, rather than only when its modification time
+// or size changes, as a rotated App ID may have the same length and be written within the granularity of the mtime.
+func (f *FileCredentials) reload(now time.Time) error {
+	f.checkedAt = now
+
+	b, err := os.ReadFile(f.path)
+	if err != nil {
+		return err
+	}
+
+	appID := strings.TrimSpace(string(b))
+	if appID == "" {
+		return fmt.Errorf("file %s: %w", f.path, ErrNoCredentials)
+	}
+
+	f.appID = appID
+
+	return nil
+}
@@ -0,0 +1,150 @@
+package oxr_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jamieaitken/oxr"
+)
+
+func TestEnvCredentials(t *testing.T) {
+	tests := []struct {
+		name          string
+		givenValue    string
+		expectedAppID string
+		expectedErr   error
+	}{
+		{
+			name:          "given variable set, expect app id",
+			givenValue:    " from-env\n",
+			expectedAppID: "from-env",
+		},
+		{
+			name:        "given variable empty, expect error",
+			givenValue:  "",
+			expectedErr: oxr.ErrNoCredentials,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Setenv("OXR_TEST_APP_ID", test.givenValue)
+
+			actual, err := oxr.EnvCredentials("OXR_TEST_APP_ID").AppID(context.Background())
+			if !errors.Is(err, test.expectedErr) {
+				t.Fatalf("expected %v, got %v", test.expectedErr, err)
+			}
+
+			if !cmp.Equal(actual, test.expectedAppID) {
+				t.Fatal(cmp.Diff(actual, test.expectedAppID))
+			}
+		})
+	}
+}
+
+func TestFileCredentials(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app_id")
+
+	_, err := oxr.NewFileCredentials(path)
+	if err == nil {
+		t.Fatal("expected error for missing file")
+	}
+
+	writeFile(t, path, "first\n")
+
+	provider, err := oxr.NewFileCredentials(path, oxr.FileCredentialsWithInterval(0))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	doer := &sequenceDoer{GivenResults: []mockResult{{StatusCode: http.StatusOK, Body: successfulLatest()}}}
+	c := oxr.New(oxr.WithCredentialsProvider(provider), oxr.WithDoer(doer))
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	steps := []func(){
+		func() {},
+		func() {
+			// Rotated to an App ID of the same length without the modification time changing.
+			writeFile(t, path, "fifth\n")
+			if err := os.Chtimes(path, info.ModTime(), info.ModTime()); err != nil {
+				t.Fatal(err)
+			}
+		},
+		func() { writeFile(t, path, "rotated\n") },
+		func() { _ = os.Remove(path) },
+	}
+	for _, step := range steps {
+		step()
+
+		if _, err := c.Latest(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var actual []string
+	for _, u := range doer.SpyURLs {
+		parsed, err := url.Parse(u)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		actual = append(actual, parsed.Query().Get("app_id"))
+	}
+
+	expected := []string{"first", "fifth", "rotated", "rotated"}
+	if !cmp.Equal(actual, expected) {
+		t.Fatal(cmp.Diff(actual, expected))
+	}
+}
+
+func TestWithCredentialsProvider_Error(t *testing.T) {
+	doer := &sequenceDoer{GivenResults: []mockResult{{StatusCode: http.StatusOK, Body: successfulLatest()}}}
+	c := oxr.New(oxr.WithCredentialsProvider(oxr.EnvCredentials("OXR_TEST_UNSET_APP_ID")), oxr.WithDoer(doer))
+
+	_, err := c.Latest(context.Background())
+	if !errors.Is(err, oxr.ErrNoCredentials) {
+		t.Fatalf("expected %v, got %v", oxr.ErrNoCredentials, err)
+	}
+
+	if len(doer.SpyURLs) != 0 {
+		t.Fatalf("expected no requests, got %d", len(doer.SpyURLs))
+	}
+}
+
+func TestWithCredentialsProvider_Failover(t *testing.T) {
+	doer := &sequenceDoer{GivenResults: []mockResult{{StatusCode: http.StatusOK, Body: successfulLatest()}}}
+	c := oxr.New(
+		oxr.WithCredentialsProvider(oxr.EnvCredentials("OXR_TEST_UNSET_APP_ID"), oxr.StaticCredentials("second")),
+		oxr.WithDoer(doer),
+	)
+
+	_, err := c.Latest(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{
+		"https://openexchangerates.org/api/latest.json?app_id=second&prettyprint=false&show_alternative=false",
+	}
+	if !cmp.Equal(doer.SpyURLs, expected) {
+		t.Fatal(cmp.Diff(doer.SpyURLs, expected))
+	}
+}
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+
+	err := os.WriteFile(path, []byte(content), 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
@@ -4,6 +4,7 @@ type currenciesParams struct {
 	showAlternative bool
 	showInactive    bool
 	prettyPrint     bool
+	meta            *ResponseMeta
 }
 
 // CurrenciesOption allows the client to specify values for a currencies request.
@@ -29,3 +30,10 @@ func CurrenciesWithPrettyPrint(active bool) CurrenciesOption {
 		p.prettyPrint = active
 	}
 }
+
+// CurrenciesWithMeta populates meta with how the result of the currencies request was obtained.
+func CurrenciesWithMeta(meta *ResponseMeta) CurrenciesOption {
+	return func(p *currenciesParams) {
+		p.meta = meta
+	}
+}
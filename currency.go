@@ -0,0 +1,145 @@
+package oxr
+
+import (
+	// Embeds the ISO 4217 currency registry.
+	_ "embed"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// ErrInvalidCurrency is returned when a currency code is not in the registry.
+var ErrInvalidCurrency = errors.New("invalid currency code")
+
+//go:embed iso4217.csv
+var iso4217CSV string
+
+var (
+	registryOnce sync.Once
+	registry     map[Currency]CurrencyInfo
+)
+
+// Currency is a currency code, such as CurrencyGBP. Options take currency codes as strings, which the untyped currency
+// constants may be given as.
+type Currency string
+
+// CurrencyInfo describes a currency in the registry.
+type CurrencyInfo struct {
+	Code Currency
+	// Numeric is the ISO 4217 numeric code, such as "826". It is empty for alternative currencies.
+	Numeric string
+	Name    string
+	// MinorUnits is the number of decimal places of the minor unit. It is -1 for currencies without one, such as gold.
+	MinorUnits int32
+	// Alternative is true for currencies which OXR offers as alternatives, such as digital currencies.
+	Alternative bool
+}
+
+// ParseCurrency returns the Currency for the code, ignoring case and surrounding whitespace. Codes which are not in the
+// registry return ErrInvalidCurrency.
+func ParseCurrency(code string) (Currency, error) {
+	c := Currency(strings.ToUpper(strings.TrimSpace(code)))
+
+	if _, ok := c.Info(); !ok {
+		return "", fmt.Errorf("%q: %w", code, ErrInvalidCurrency)
+	}
+
+	return c, nil
+}
+
+// Info returns the registry entry for the currency. The boolean is false should the currency not be in the registry.
+func (c Currency) Info() (CurrencyInfo, bool) {
+	registryOnce.Do(func() {
+		registry = loadRegistry()
+	})
+
+	info, ok := registry[c]
+
+	return info, ok
+}
+
+// String implements a fmt.Stringer for Currency.
+func (c Currency) String() string {
+	return string(c)
+}
+
+// Currencies returns every currency in the registry, ordered by code.
+func Currencies() []CurrencyInfo {
+	registryOnce.Do(func() {
+		registry = loadRegistry()
+	})
+
+	infos := make([]CurrencyInfo, 0, len(registry))
+	for _, info := range registry {
+		infos = append(infos, info)
+	}
+
+	sort.Slice(infos, func(i, j int) bool {
+		return infos[i].Code < infos[j].Code
+	})
+
+	return infos
+}
+
+func loadRegistry() map[Currency]CurrencyInfo {
+	records, err := csv.NewReader(strings.NewReader(iso4217CSV)).ReadAll()
+	if err != nil {
+		panic("oxr: invalid embedded iso4217.csv: " + err.Error())
+	}
+
+	table := make(map[Currency]CurrencyInfo, len(records))
+	for _, record := range records[1:] {
+		units := int64(-1)
+		if record[2] != "" {
+			units, err = strconv.ParseInt(record[2], 10, 32)
+			if err != nil {
+				panic("oxr: invalid embedded iso4217.csv: " + err.Error())
+			}
+		}
+
+		code := Currency(record[0])
+		table[code] = CurrencyInfo{
+			Code:        code,
+			Numeric:     record[1],
+			Name:        record[3],
+			MinorUnits:  int32(units),
+			Alternative: record[4] == "true",
+		}
+	}
+
+	return table
+}
+
+// validCurrency normalises the currency code, also returning ErrInvalidCurrency should it not be in the registry.
+func validCurrency(code string) (string, error) {
+	_, err := ParseCurrency(code)
+
+	return strings.ToUpper(strings.TrimSpace(code)), err
+}
+
+// validCurrencies normalises the currency codes, also returning ErrInvalidCurrency for the first which is not in the
+// registry.
+func validCurrencies(codes []string) ([]string, error) {
+	var invalid error
+
+	normalised := make([]string, len(codes))
+	for i, code := range codes {
+		c, err := validCurrency(code)
+		normalised[i], invalid = c, firstErr(invalid, err)
+	}
+
+	return normalised, invalid
+}
+
+// firstErr returns existing, should it be set, otherwise err.
+func firstErr(existing, err error) error {
+	if existing != nil {
+		return existing
+	}
+
+	return err
+}
@@ -0,0 +1,190 @@
+package oxr
+
+// ISO 4217 currency codes, including withdrawn codes which OXR still serves, along with the alternative currencies
+// offered by OXR.
+const (
+	CurrencyAED = "AED" // UAE Dirham
+	CurrencyAFN = "AFN" // Afghani
+	CurrencyALL = "ALL" // Lek
+	CurrencyAMD = "AMD" // Armenian Dram
+	CurrencyANG = "ANG" // Netherlands Antillean Guilder
+	CurrencyAOA = "AOA" // Kwanza
+	CurrencyARS = "ARS" // Argentine Peso
+	CurrencyAUD = "AUD" // Australian Dollar
+	CurrencyAWG = "AWG" // Aruban Florin
+	CurrencyAZN = "AZN" // Azerbaijan Manat
+	CurrencyBAM = "BAM" // Convertible Mark
+	CurrencyBBD = "BBD" // Barbados Dollar
+	CurrencyBDT = "BDT" // Taka
+	CurrencyBHD = "BHD" // Bahraini Dinar
+	CurrencyBIF = "BIF" // Burundi Franc
+	CurrencyBMD = "BMD" // Bermudian Dollar
+	CurrencyBND = "BND" // Brunei Dollar
+	CurrencyBOB = "BOB" // Boliviano
+	CurrencyBOV = "BOV" // Mvdol
+	CurrencyBRL = "BRL" // Brazilian Real
+	CurrencyBSD = "BSD" // Bahamian Dollar
+	CurrencyBTN = "BTN" // Ngultrum
+	CurrencyBWP = "BWP" // Pula
+	CurrencyBYN = "BYN" // Belarusian Ruble
+	CurrencyBZD = "BZD" // Belize Dollar
+	CurrencyCAD = "CAD" // Canadian Dollar
+	CurrencyCDF = "CDF" // Congolese Franc
+	CurrencyCHE = "CHE" // WIR Euro
+	CurrencyCHF = "CHF" // Swiss Franc
+	CurrencyCHW = "CHW" // WIR Franc
+	CurrencyCLF = "CLF" // Unidad de Fomento
+	CurrencyCLP = "CLP" // Chilean Peso
+	CurrencyCNY = "CNY" // Yuan Renminbi
+	CurrencyCOP = "COP" // Colombian Peso
+	CurrencyCOU = "COU" // Unidad de Valor Real
+	CurrencyCRC = "CRC" // Costa Rican Colon
+	CurrencyCUC = "CUC" // Peso Convertible
+	CurrencyCUP = "CUP" // Cuban Peso
+	CurrencyCVE = "CVE" // Cabo Verde Escudo
+	CurrencyCZK = "CZK" // Czech Koruna
+	CurrencyDJF = "DJF" // Djibouti Franc
+	CurrencyDKK = "DKK" // Danish Krone
+	CurrencyDOP = "DOP" // Dominican Peso
+	CurrencyDZD = "DZD" // Algerian Dinar
+	CurrencyEGP = "EGP" // Egyptian Pound
+	CurrencyERN = "ERN" // Nakfa
+	CurrencyETB = "ETB" // Ethiopian Birr
+	CurrencyEUR = "EUR" // Euro
+	CurrencyFJD = "FJD" // Fiji Dollar
+	CurrencyFKP = "FKP" // Falkland Islands Pound
+	CurrencyGBP = "GBP" // Pound Sterling
+	CurrencyGEL = "GEL" // Lari
+	CurrencyGHS = "GHS" // Ghana Cedi
+	CurrencyGIP = "GIP" // Gibraltar Pound
+	CurrencyGMD = "GMD" // Dalasi
+	CurrencyGNF = "GNF" // Guinean Franc
+	CurrencyGTQ = "GTQ" // Quetzal
+	CurrencyGYD = "GYD" // Guyana Dollar
+	CurrencyHKD = "HKD" // Hong Kong Dollar
+	CurrencyHNL = "HNL" // Lempira
+	CurrencyHRK = "HRK" // Kuna
+	CurrencyHTG = "HTG" // Gourde
+	CurrencyHUF = "HUF" // Forint
+	CurrencyIDR = "IDR" // Rupiah
+	CurrencyILS = "ILS" // New Israeli Sheqel
+	CurrencyINR = "INR" // Indian Rupee
+	CurrencyIQD = "IQD" // Iraqi Dinar
+	CurrencyIRR = "IRR" // Iranian Rial
+	CurrencyISK = "ISK" // Iceland Krona
+	CurrencyJMD = "JMD" // Jamaican Dollar
+	CurrencyJOD = "JOD" // Jordanian Dinar
+	CurrencyJPY = "JPY" // Yen
+	CurrencyKES = "KES" // Kenyan Shilling
+	CurrencyKGS = "KGS" // Som
+	CurrencyKHR = "KHR" // Riel
+	CurrencyKMF = "KMF" // Comorian Franc
+	CurrencyKPW = "KPW" // North Korean Won
+	CurrencyKRW = "KRW" // Won
+	CurrencyKWD = "KWD" // Kuwaiti Dinar
+	CurrencyKYD = "KYD" // Cayman Islands Dollar
+	CurrencyKZT = "KZT" // Tenge
+	CurrencyLAK = "LAK" // Lao Kip
+	CurrencyLBP = "LBP" // Lebanese Pound
+	CurrencyLKR = "LKR" // Sri Lanka Rupee
+	CurrencyLRD = "LRD" // Liberian Dollar
+	CurrencyLSL = "LSL" // Loti
+	CurrencyLYD = "LYD" // Libyan Dinar
+	CurrencyMAD = "MAD" // Moroccan Dirham
+	CurrencyMDL = "MDL" // Moldovan Leu
+	CurrencyMGA = "MGA" // Malagasy Ariary
+	CurrencyMKD = "MKD" // Denar
+	CurrencyMMK = "MMK" // Kyat
+	CurrencyMNT = "MNT" // Tugrik
+	CurrencyMOP = "MOP" // Pataca
+	CurrencyMRU = "MRU" // Ouguiya
+	CurrencyMUR = "MUR" // Mauritius Rupee
+	CurrencyMVR = "MVR" // Rufiyaa
+	CurrencyMWK = "MWK" // Malawi Kwacha
+	CurrencyMXN = "MXN" // Mexican Peso
+	CurrencyMXV = "MXV" // Mexican Unidad de Inversion (UDI)
+	CurrencyMYR = "MYR" // Malaysian Ringgit
+	CurrencyMZN = "MZN" // Mozambique Metical
+	CurrencyNAD = "NAD" // Namibia Dollar
+	CurrencyNGN = "NGN" // Naira
+	CurrencyNIO = "NIO" // Cordoba Oro
+	CurrencyNOK = "NOK" // Norwegian Krone
+	CurrencyNPR = "NPR" // Nepalese Rupee
+	CurrencyNZD = "NZD" // New Zealand Dollar
+	CurrencyOMR = "OMR" // Rial Omani
+	CurrencyPAB = "PAB" // Balboa
+	CurrencyPEN = "PEN" // Sol
+	CurrencyPGK = "PGK" // Kina
+	CurrencyPHP = "PHP" // Philippine Peso
+	CurrencyPKR = "PKR" // Pakistan Rupee
+	CurrencyPLN = "PLN" // Zloty
+	CurrencyPYG = "PYG" // Guarani
+	CurrencyQAR = "QAR" // Qatari Rial
+	CurrencyRON = "RON" // Romanian Leu
+	CurrencyRSD = "RSD" // Serbian Dinar
+	CurrencyRUB = "RUB" // Russian Ruble
+	CurrencyRWF = "RWF" // Rwanda Franc
+	CurrencySAR = "SAR" // Saudi Riyal
+	CurrencySBD = "SBD" // Solomon Islands Dollar
+	CurrencySCR = "SCR" // Seychelles Rupee
+	CurrencySDG = "SDG" // Sudanese Pound
+	CurrencySEK = "SEK" // Swedish Krona
+	CurrencySGD = "SGD" // Singapore Dollar
+	CurrencySHP = "SHP" // Saint Helena Pound
+	CurrencySLE = "SLE" // Leone
+	CurrencySLL = "SLL" // Leone
+	CurrencySOS = "SOS" // Somali Shilling
+	CurrencySRD = "SRD" // Surinam Dollar
+	CurrencySSP = "SSP" // South Sudanese Pound
+	CurrencySTN = "STN" // Dobra
+	CurrencySVC = "SVC" // El Salvador Colon
+	CurrencySYP = "SYP" // Syrian Pound
+	CurrencySZL = "SZL" // Lilangeni
+	CurrencyTHB = "THB" // Baht
+	CurrencyTJS = "TJS" // Somoni
+	CurrencyTMT = "TMT" // Turkmenistan New Manat
+	CurrencyTND = "TND" // Tunisian Dinar
+	CurrencyTOP = "TOP" // Pa'anga
+	CurrencyTRY = "TRY" // Turkish Lira
+	CurrencyTTD = "TTD" // Trinidad and Tobago Dollar
+	CurrencyTWD = "TWD" // New Taiwan Dollar
+	CurrencyTZS = "TZS" // Tanzanian Shilling
+	CurrencyUAH = "UAH" // Hryvnia
+	CurrencyUGX = "UGX" // Uganda Shilling
+	CurrencyUSD = "USD" // US Dollar
+	CurrencyUSN = "USN" // US Dollar (Next day)
+	CurrencyUYI = "UYI" // Uruguay Peso en Unidades Indexadas (UI)
+	CurrencyUYU = "UYU" // Peso Uruguayo
+	CurrencyUYW = "UYW" // Unidad Previsional
+	CurrencyUZS = "UZS" // Uzbekistan Sum
+	CurrencyVED = "VED" // Bolivar Soberano
+	CurrencyVES = "VES" // Bolivar Soberano
+	CurrencyVND = "VND" // Dong
+	CurrencyVUV = "VUV" // Vatu
+	CurrencyWST = "WST" // Tala
+	CurrencyXAF = "XAF" // CFA Franc BEAC
+	CurrencyXAG = "XAG" // Silver
+	CurrencyXAU = "XAU" // Gold
+	CurrencyXCD = "XCD" // East Caribbean Dollar
+	CurrencyXCG = "XCG" // Caribbean Guilder
+	CurrencyXDR = "XDR" // SDR (Special Drawing Right)
+	CurrencyXOF = "XOF" // CFA Franc BCEAO
+	CurrencyXPD = "XPD" // Palladium
+	CurrencyXPF = "XPF" // CFP Franc
+	CurrencyXPT = "XPT" // Platinum
+	CurrencyYER = "YER" // Yemeni Rial
+	CurrencyZAR = "ZAR" // Rand
+	CurrencyZMW = "ZMW" // Zambian Kwacha
+	CurrencyZWG = "ZWG" // Zimbabwe Gold
+	CurrencyZWL = "ZWL" // Zimbabwe Dollar
+
+	// Alternative currencies, which are only included when requested.
+	CurrencyBTC = "BTC" // Bitcoin
+	CurrencyCNH = "CNH" // Chinese Yuan (Offshore)
+	CurrencyGGP = "GGP" // Guernsey Pound
+	CurrencyIMP = "IMP" // Isle of Man Pound
+	CurrencyJEP = "JEP" // Jersey Pound
+	CurrencyMRO = "MRO" // Mauritanian Ouguiya (pre-2018)
+	CurrencySTD = "STD" // Sao Tome and Principe Dobra (pre-2018)
+	CurrencyVEF = "VEF" // Venezuelan Bolivar Fuerte (old)
+)
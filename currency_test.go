@@ -0,0 +1,217 @@
+package oxr_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sort"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jamieaitken/oxr"
+)
+
+func TestParseCurrency(t *testing.T) {
+	tests := []struct {
+		name        string
+		given       string
+		expected    oxr.Currency
+		expectedErr error
+	}{
+		{
+			name:     "given code, expect currency",
+			given:    "GBP",
+			expected: oxr.CurrencyGBP,
+		},
+		{
+			name:     "given lower case code with whitespace, expect currency",
+			given:    " jpy ",
+			expected: oxr.CurrencyJPY,
+		},
+		{
+			name:     "given alternative currency, expect currency",
+			given:    "btc",
+			expected: oxr.CurrencyBTC,
+		},
+		{
+			name:     "given withdrawn code still served by OXR, expect currency",
+			given:    "hrk",
+			expected: oxr.CurrencyHRK,
+		},
+		{
+			name:        "given typo, expect error",
+			given:       "GPB",
+			expectedErr: oxr.ErrInvalidCurrency,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			actual, err := oxr.ParseCurrency(test.given)
+			if !errors.Is(err, test.expectedErr) {
+				t.Fatalf("expected %v, got %v", test.expectedErr, err)
+			}
+
+			if !cmp.Equal(actual, test.expected) {
+				t.Fatal(cmp.Diff(actual, test.expected))
+			}
+		})
+	}
+}
+
+func TestCurrency_Info(t *testing.T) {
+	tests := []struct {
+		given    oxr.Currency
+		expected oxr.CurrencyInfo
+	}{
+		{
+			given:    oxr.CurrencyGBP,
+			expected: oxr.CurrencyInfo{Code: "GBP", Numeric: "826", Name: "Pound Sterling", MinorUnits: 2},
+		},
+		{
+			given:    oxr.CurrencyALL,
+			expected: oxr.CurrencyInfo{Code: "ALL", Numeric: "008", Name: "Lek", MinorUnits: 2},
+		},
+		{
+			given:    oxr.CurrencyXAU,
+			expected: oxr.CurrencyInfo{Code: "XAU", Numeric: "959", Name: "Gold", MinorUnits: -1},
+		},
+		{
+			given:    oxr.CurrencyBTC,
+			expected: oxr.CurrencyInfo{Code: "BTC", Name: "Bitcoin", MinorUnits: 8, Alternative: true},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.given.String(), func(t *testing.T) {
+			actual, ok := test.given.Info()
+			if !ok {
+				t.Fatal("expected currency in registry")
+			}
+
+			if !cmp.Equal(actual, test.expected) {
+				t.Fatal(cmp.Diff(actual, test.expected))
+			}
+		})
+	}
+}
+
+func TestCurrencies(t *testing.T) {
+	actual := oxr.Currencies()
+
+	if !sort.SliceIsSorted(actual, func(i, j int) bool { return actual[i].Code < actual[j].Code }) {
+		t.Fatal("expected currencies ordered by code")
+	}
+
+	for _, info := range actual {
+		if _, err := oxr.ParseCurrency(info.Code.String()); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestOptions_ValidateCurrencies(t *testing.T) {
+	tests := []struct {
+		name      string
+		givenCall func(c oxr.Client) error
+	}{
+		{
+			name: "latest base",
+			givenCall: func(c oxr.Client) error {
+				_, err := c.Latest(context.Background(), oxr.LatestForBaseCurrency("GPB"))
+				return err
+			},
+		},
+		{
+			name: "latest destinations",
+			givenCall: func(c oxr.Client) error {
+				_, err := c.Latest(context.Background(), oxr.LatestForDestinationCurrencies([]string{"EUR", "GPB"}))
+				return err
+			},
+		},
+		{
+			name: "historical destinations",
+			givenCall: func(c oxr.Client) error {
+				_, err := c.Historical(context.Background(), oxr.HistoricalForDestinationCurrencies([]string{"GPB"}))
+				return err
+			},
+		},
+		{
+			name: "time series base",
+			givenCall: func(c oxr.Client) error {
+				_, err := c.TimeSeries(context.Background(), oxr.TimeSeriesForBaseCurrency("GPB"))
+				return err
+			},
+		},
+		{
+			name: "convert destination",
+			givenCall: func(c oxr.Client) error {
+				_, err := c.Convert(context.Background(),
+					oxr.ConvertForBaseCurrency(oxr.CurrencyGBP),
+					oxr.ConvertForDestinationCurrency("GPB"),
+				)
+				return err
+			},
+		},
+		{
+			name: "ohlc destinations",
+			givenCall: func(c oxr.Client) error {
+				_, err := c.OpenHighLowClose(context.Background(), oxr.OHLCForDestinationCurrencies([]string{"GPB"}))
+				return err
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			doer := &sequenceDoer{GivenResults: []mockResult{{StatusCode: http.StatusOK, Body: successfulLatest()}}}
+			c := oxr.New(oxr.WithAppID("test"), oxr.WithDoer(doer))
+
+			err := test.givenCall(c)
+			if !errors.Is(err, oxr.ErrInvalidCurrency) {
+				t.Fatalf("expected %v, got %v", oxr.ErrInvalidCurrency, err)
+			}
+
+			if len(doer.SpyURLs) != 0 {
+				t.Fatalf("expected no requests, got %d", len(doer.SpyURLs))
+			}
+		})
+	}
+}
+
+func TestWithCurrencyValidation(t *testing.T) {
+	tests := []struct {
+		name         string
+		givenEnabled bool
+		expectedURLs []string
+		expectedErr  error
+	}{
+		{
+			name:         "given validation enabled, expect unknown code rejected",
+			givenEnabled: true,
+			expectedErr:  oxr.ErrInvalidCurrency,
+		},
+		{
+			name:         "given validation disabled, expect unknown code requested",
+			givenEnabled: false,
+			expectedURLs: []string{
+				"https://openexchangerates.org/api/latest.json?app_id=test&base=VED&prettyprint=false&show_alternative=false&symbols=GBP%2CXYZ",
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			doer := &sequenceDoer{GivenResults: []mockResult{{StatusCode: http.StatusOK, Body: successfulLatest()}}}
+			c := oxr.New(oxr.WithAppID("test"), oxr.WithDoer(doer), oxr.WithCurrencyValidation(test.givenEnabled))
+
+			_, err := c.Latest(context.Background(),
+				oxr.LatestForBaseCurrency("ved"),
+				oxr.LatestForDestinationCurrencies([]string{oxr.CurrencyGBP, "xyz"}),
+			)
+			if !errors.Is(err, test.expectedErr) {
+				t.Fatalf("expected %v, got %v", test.expectedErr, err)
+			}
+
+			if !cmp.Equal(doer.SpyURLs, test.expectedURLs) {
+				t.Fatal(cmp.Diff(doer.SpyURLs, test.expectedURLs))
+			}
+		})
+	}
+}
@@ -0,0 +1,288 @@
+package oxr
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// maxDecimalExponent bounds the exponent accepted by ParseDecimal, preventing huge allocations from hostile input.
+const maxDecimalExponent = 1000
+
+var (
+	ErrInvalidDecimal   = errors.New("invalid decimal")
+	ErrDivisionByZero   = errors.New("division by zero")
+	ErrCurrencyMismatch = errors.New("currencies do not match")
+)
+
+var bigTen = big.NewInt(10)
+
+// Decimal is an exact decimal number, represented as an integer coefficient scaled by a power of ten. The zero value
+// is zero. Decimals are immutable, so are safe to copy and share.
+type Decimal struct {
+	coef  *big.Int
+	scale int32
+}
+
+// NewDecimal instantiates the Decimal value × 10^-scale, for example NewDecimal(12345, 2) is 123.45.
+func NewDecimal(value int64, scale int32) Decimal {
+	return scaled(big.NewInt(value), scale)
+}
+
+// ParseDecimal parses a decimal number, such as "-123.45" or "1.5e-3", exactly.
+func ParseDecimal(s string) (Decimal, error) {
+	mantissa, exponent := s, int64(0)
+	if i := strings.IndexAny(s, "eE"); i >= 0 {
+		var err error
+		exponent, err = strconv.ParseInt(s[i+1:], 10, 32)
+		if err != nil || exponent > maxDecimalExponent || exponent < -maxDecimalExponent {
+			return Decimal{}, fmt.Errorf("%q: %w", s, ErrInvalidDecimal)
+		}
+
+		mantissa = s[:i]
+	}
+
+	integer, fraction := mantissa, ""
+	if i := strings.IndexByte(mantissa, '.'); i >= 0 {
+		integer, fraction = mantissa[:i], mantissa[i+1:]
+	}
+
+	digits := strings.TrimPrefix(strings.TrimPrefix(integer, "-"), "+")
+	if digits+fraction == "" || !isDigits(digits) || !isDigits(fraction) || len(integer)-len(digits) > 1 {
+		return Decimal{}, fmt.Errorf("%q: %w", s, ErrInvalidDecimal)
+	}
+
+	coef, ok := new(big.Int).SetString(integer+fraction, 10)
+	if !ok {
+		return Decimal{}, fmt.Errorf("%q: %w", s, ErrInvalidDecimal)
+	}
+
+	scale := int64(len(fraction)) - exponent
+	if scale < 0 {
+		coef.Mul(coef, pow10(int32(-scale)))
+		scale = 0
+	}
+
+	return Decimal{coef: coef, scale: int32(scale)}, nil
+}
+
+// MustParseDecimal is like ParseDecimal but panics should the number be invalid. It is intended for constants.
+func MustParseDecimal(s string) Decimal {
+	d, err := ParseDecimal(s)
+	if err != nil {
+		panic(err)
+	}
+
+	return d
+}
+
+// decimalFromFloat converts a float64 into the shortest Decimal which converts back into the same float64.
+func decimalFromFloat(f float64) (Decimal, error) {
+	return ParseDecimal(strconv.FormatFloat(f, 'g', -1, 64))
+}
+
+// Scale returns the number of digits after the decimal point.
+func (d Decimal) Scale() int32 {
+	return d.scale
+}
+
+// Sign returns -1, 0 or +1 depending on whether d is negative, zero or positive.
+func (d Decimal) Sign() int {
+	return d.int().Sign()
+}
+
+// IsZero reports whether d is zero.
+func (d Decimal) IsZero() bool {
+	return d.Sign() == 0
+}
+
+// Cmp compares d and other, returning -1, 0 or +1.
+func (d Decimal) Cmp(other Decimal) int {
+	a, b := align(d, other)
+
+	return a.Cmp(b)
+}
+
+// Equal reports whether d and other are the same number, regardless of scale.
+func (d Decimal) Equal(other Decimal) bool {
+	return d.Cmp(other) == 0
+}
+
+// Neg returns -d.
+func (d Decimal) Neg() Decimal {
+	return Decimal{coef: new(big.Int).Neg(d.int()), scale: d.scale}
+}
+
+// Add returns d + other.
+func (d Decimal) Add(other Decimal) Decimal {
+	a, b := align(d, other)
+
+	return Decimal{coef: a.Add(a, b), scale: maxScale(d, other)}
+}
+
+// Sub returns d - other.
+func (d Decimal) Sub(other Decimal) Decimal {
+	a, b := align(d, other)
+
+	return Decimal{coef: a.Sub(a, b), scale: maxScale(d, other)}
+}
+
+// Mul returns d × other exactly.
+func (d Decimal) Mul(other Decimal) Decimal {
+	return Decimal{coef: new(big.Int).Mul(d.int(), other.int()), scale: d.scale + other.scale}
+}
+
+// Div returns d ÷ other rounded to the given number of decimal places according to the policy.
+func (d Decimal) Div(other Decimal, places int32, policy RoundingPolicy) (Decimal, error) {
+	if other.IsZero() {
+		return Decimal{}, ErrDivisionByZero
+	}
+
+	num, den := new(big.Int).Set(d.int()), new(big.Int).Set(other.int())
+
+	// d ÷ other × 10^places = d.coef × 10^(places - d.scale + other.scale) ÷ other.coef
+	exp := int64(places) - int64(d.scale) + int64(other.scale)
+	if exp >= 0 {
+		num.Mul(num, pow10(int32(exp)))
+	} else {
+		den.Mul(den, pow10(int32(-exp)))
+	}
+
+	return scaled(roundQuo(num, den, policy), places), nil
+}
+
+// Round returns d rounded to the given number of decimal places according to the policy, where negative places round
+// to the left of the decimal point, for example -2 rounds to the nearest hundred. Should d have no more places, it is
+// returned unchanged.
+func (d Decimal) Round(places int32, policy RoundingPolicy) Decimal {
+	if places >= d.scale {
+		return d
+	}
+
+	return scaled(roundQuo(d.int(), pow10(d.scale-places), policy), places)
+}
+
+// trimmed returns d at the smallest scale which represents it exactly, dropping trailing zeros after the decimal point.
+func (d Decimal) trimmed() Decimal {
+	coef, scale := new(big.Int).Set(d.int()), d.scale
+
+	quo, rem := new(big.Int), new(big.Int)
+	for scale > 0 {
+		quo.QuoRem(coef, bigTen, rem)
+		if rem.Sign() != 0 {
+			break
+		}
+
+		coef.Set(quo)
+		scale--
+	}
+
+	return Decimal{coef: coef, scale: scale}
+}
+
+// String formats d without an exponent, keeping its scale, for example "123.450".
+func (d Decimal) String() string {
+	digits := new(big.Int).Abs(d.int()).String()
+
+	sign := ""
+	if d.Sign() < 0 {
+		sign = "-"
+	}
+
+	if d.scale == 0 {
+		return sign + digits
+	}
+
+	if pad := int(d.scale) - len(digits) + 1; pad > 0 {
+		digits = strings.Repeat("0", pad) + digits
+	}
+
+	point := len(digits) - int(d.scale)
+
+	return sign + digits[:point] + "." + digits[point:]
+}
+
+// Float64 returns the nearest float64 to d.
+func (d Decimal) Float64() float64 {
+	f, _ := strconv.ParseFloat(d.String(), 64)
+
+	return f
+}
+
+// MarshalJSON encodes d as a JSON number.
+func (d Decimal) MarshalJSON() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// UnmarshalJSON decodes a JSON number, or a string containing one, exactly.
+func (d *Decimal) UnmarshalJSON(b []byte) error {
+	s := string(b)
+	if s == "null" {
+		return nil
+	}
+
+	if unquoted, err := strconv.Unquote(s); err == nil {
+		s = unquoted
+	}
+
+	parsed, err := ParseDecimal(s)
+	if err != nil {
+		return err
+	}
+
+	*d = parsed
+
+	return nil
+}
+
+// scaled returns the Decimal coef × 10^-scale, keeping the scale no lower than zero as every Decimal's is.
+func scaled(coef *big.Int, scale int32) Decimal {
+	if scale < 0 {
+		return Decimal{coef: coef.Mul(coef, pow10(-scale))}
+	}
+
+	return Decimal{coef: coef, scale: scale}
+}
+
+func (d Decimal) int() *big.Int {
+	if d.coef == nil {
+		return new(big.Int)
+	}
+
+	return d.coef
+}
+
+// align returns the coefficients of a and b at the same scale.
+func align(a, b Decimal) (*big.Int, *big.Int) {
+	scale := maxScale(a, b)
+
+	return rescale(a, scale), rescale(b, scale)
+}
+
+func rescale(d Decimal, scale int32) *big.Int {
+	return new(big.Int).Mul(d.int(), pow10(scale-d.scale))
+}
+
+func maxScale(a, b Decimal) int32 {
+	if a.scale > b.scale {
+		return a.scale
+	}
+
+	return b.scale
+}
+
+func pow10(n int32) *big.Int {
+	return new(big.Int).Exp(bigTen, big.NewInt(int64(n)), nil)
+}
+
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+
+	return true
+}
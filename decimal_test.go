@@ -0,0 +1,196 @@
+package oxr_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jamieaitken/oxr"
+)
+
+func TestParseDecimal(t *testing.T) {
+	tests := []struct {
+		name        string
+		given       string
+		expected    string
+		expectedErr error
+	}{
+		{
+			name:     "given integer, expect no decimal places",
+			given:    "1225",
+			expected: "1225",
+		},
+		{
+			name:     "given fraction, expect scale kept",
+			given:    "0.764000",
+			expected: "0.764000",
+		},
+		{
+			name:     "given negative fraction without integer, expect leading zero",
+			given:    "-.05",
+			expected: "-0.05",
+		},
+		{
+			name:     "given negative exponent, expect scale increased",
+			given:    "1.5e-3",
+			expected: "0.0015",
+		},
+		{
+			name:     "given positive exponent, expect integer",
+			given:    "1.5E3",
+			expected: "1500",
+		},
+		{
+			name:        "given letters, expect error",
+			given:       "1.2a",
+			expectedErr: oxr.ErrInvalidDecimal,
+		},
+		{
+			name:        "given sign only, expect error",
+			given:       "-",
+			expectedErr: oxr.ErrInvalidDecimal,
+		},
+		{
+			name:        "given huge exponent, expect error",
+			given:       "1e999999",
+			expectedErr: oxr.ErrInvalidDecimal,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			actual, err := oxr.ParseDecimal(test.given)
+			if !errors.Is(err, test.expectedErr) {
+				t.Fatalf("expected %v, got %v", test.expectedErr, err)
+			}
+
+			if err != nil {
+				return
+			}
+
+			if !cmp.Equal(actual.String(), test.expected) {
+				t.Fatal(cmp.Diff(actual.String(), test.expected))
+			}
+		})
+	}
+}
+
+func TestDecimal_Arithmetic(t *testing.T) {
+	a, b := oxr.MustParseDecimal("0.1"), oxr.MustParseDecimal("0.2")
+
+	tests := []struct {
+		name     string
+		actual   oxr.Decimal
+		expected string
+	}{
+		{name: "add", actual: a.Add(b), expected: "0.3"},
+		{name: "sub", actual: a.Sub(b), expected: "-0.1"},
+		{name: "mul", actual: a.Mul(b), expected: "0.02"},
+		{name: "neg", actual: a.Neg(), expected: "-0.1"},
+		{name: "new decimal", actual: oxr.NewDecimal(12345, 2), expected: "123.45"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if !cmp.Equal(test.actual.String(), test.expected) {
+				t.Fatal(cmp.Diff(test.actual.String(), test.expected))
+			}
+		})
+	}
+}
+
+func TestDecimal_Div(t *testing.T) {
+	tests := []struct {
+		name        string
+		givenA      string
+		givenB      string
+		givenPlaces int32
+		givenPolicy oxr.RoundingPolicy
+		expected    string
+		expectedErr error
+	}{
+		{
+			name:        "given recurring result, expect rounding to places",
+			givenA:      "2",
+			givenB:      "3",
+			givenPlaces: 4,
+			expected:    "0.6667",
+		},
+		{
+			name:        "given negative recurring result, expect rounding away from zero",
+			givenA:      "-2",
+			givenB:      "3",
+			givenPlaces: 4,
+			expected:    "-0.6667",
+		},
+		{
+			name:        "given round down policy, expect truncation",
+			givenA:      "2",
+			givenB:      "3",
+			givenPlaces: 4,
+			givenPolicy: oxr.RoundDown,
+			expected:    "0.6666",
+		},
+		{
+			name:        "given divisor with more places than result, expect exact",
+			givenA:      "1",
+			givenB:      "0.8",
+			givenPlaces: 0,
+			expected:    "1",
+		},
+		{
+			name:        "given negative places, expect rounding to the left of the point",
+			givenA:      "123456",
+			givenB:      "1",
+			givenPlaces: -2,
+			expected:    "123500",
+		},
+		{
+			name:        "given zero divisor, expect error",
+			givenA:      "1",
+			givenB:      "0",
+			expectedErr: oxr.ErrDivisionByZero,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			actual, err := oxr.MustParseDecimal(test.givenA).Div(oxr.MustParseDecimal(test.givenB), test.givenPlaces, test.givenPolicy)
+			if !errors.Is(err, test.expectedErr) {
+				t.Fatalf("expected %v, got %v", test.expectedErr, err)
+			}
+
+			if err != nil {
+				return
+			}
+
+			if !cmp.Equal(actual.String(), test.expected) {
+				t.Fatal(cmp.Diff(actual.String(), test.expected))
+			}
+		})
+	}
+}
+
+func TestDecimal_JSON(t *testing.T) {
+	var actual struct {
+		Number oxr.Decimal `json:"number"`
+		String oxr.Decimal `json:"string"`
+	}
+
+	err := json.Unmarshal([]byte(`{"number": 0.1000000000000000055511151231257827, "string": "12.50"}`), &actual)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !cmp.Equal(actual.Number.String(), "0.1000000000000000055511151231257827") {
+		t.Fatal(cmp.Diff(actual.Number.String(), "0.1000000000000000055511151231257827"))
+	}
+
+	b, err := json.Marshal(actual)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `{"number":0.1000000000000000055511151231257827,"string":12.50}`
+	if !cmp.Equal(string(b), expected) {
+		t.Fatal(cmp.Diff(string(b), expected))
+	}
+}
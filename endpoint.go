@@ -0,0 +1,20 @@
+package oxr
+
+// Endpoint identifies an OXR API endpoint.
+type Endpoint string
+
+// Available endpoints.
+const (
+	EndpointLatest     Endpoint = "latest"
+	EndpointHistorical Endpoint = "historical"
+	EndpointCurrencies Endpoint = "currencies"
+	EndpointTimeSeries Endpoint = "time-series"
+	EndpointConvert    Endpoint = "convert"
+	EndpointOHLC       Endpoint = "ohlc"
+	EndpointUsage      Endpoint = "usage"
+)
+
+// String implements a fmt.Stringer for Endpoint.
+func (e Endpoint) String() string {
+	return string(e)
+}
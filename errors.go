@@ -0,0 +1,88 @@
+package oxr
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// Error messages returned by OXR.
+// https://docs.openexchangerates.org/docs/errors
+const (
+	messageNotFound         = "not_found"
+	messageMissingAppID     = "missing_app_id"
+	messageInvalidAppID     = "invalid_app_id"
+	messageNotAllowed       = "not_allowed"
+	messageAccessRestricted = "access_restricted"
+	messageInvalidBase      = "invalid_base"
+)
+
+var (
+	ErrBadResponse      = errors.New("failed to receive successful response")
+	ErrNotFound         = errors.New("requested resource does not exist")
+	ErrMissingAppID     = errors.New("app id was not provided")
+	ErrInvalidAppID     = errors.New("app id is invalid")
+	ErrNotAllowed       = errors.New("app id is not allowed to access the requested feature")
+	ErrAccessRestricted = errors.New("app id access is restricted")
+	ErrInvalidBase      = errors.New("requested base currency is invalid")
+	ErrQuotaExceeded    = errors.New("app id has exceeded its request quota")
+	ErrAppIDsExhausted  = fmt.Errorf("every app id is restricted: %w", ErrAccessRestricted)
+)
+
+// APIError is the error payload returned by OXR for an unsuccessful request.
+type APIError struct {
+	// StatusCode is the HTTP status code of the response.
+	StatusCode  int    `json:"-"`
+	Status      int    `json:"status"`
+	Message     string `json:"message"`
+	Description string `json:"description"`
+}
+
+// Error implements the error interface for APIError.
+func (e *APIError) Error() string {
+	if e.Message == "" {
+		return fmt.Sprintf("status received: %v: %v", e.StatusCode, ErrBadResponse)
+	}
+
+	return fmt.Sprintf("status received: %v: %v: %s: %s", e.StatusCode, ErrBadResponse, e.Message, e.Description)
+}
+
+// Is allows an APIError to be compared against ErrBadResponse and the more specific sentinel errors using errors.Is.
+func (e *APIError) Is(target error) bool {
+	switch target {
+	case ErrBadResponse:
+		return true
+	case ErrQuotaExceeded:
+		return e.Message == messageAccessRestricted &&
+			(e.Status == http.StatusTooManyRequests || e.StatusCode == http.StatusTooManyRequests)
+	}
+
+	return target == messageErrors[e.Message]
+}
+
+var messageErrors = map[string]error{
+	messageNotFound:         ErrNotFound,
+	messageMissingAppID:     ErrMissingAppID,
+	messageInvalidAppID:     ErrInvalidAppID,
+	messageNotAllowed:       ErrNotAllowed,
+	messageAccessRestricted: ErrAccessRestricted,
+	messageInvalidBase:      ErrInvalidBase,
+}
+
+// newAPIError decodes the error payload of an unsuccessful response. Should the body not contain an OXR error payload,
+// only the status code is populated.
+func newAPIError(statusCode int, body []byte) *APIError {
+	apiErr := &APIError{}
+
+	if len(body) > 0 && json.Unmarshal(body, apiErr) != nil {
+		apiErr = &APIError{}
+	}
+
+	apiErr.StatusCode = statusCode
+	if apiErr.Status == 0 {
+		apiErr.Status = statusCode
+	}
+
+	return apiErr
+}
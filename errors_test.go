@@ -0,0 +1,176 @@
+package oxr_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jamieaitken/oxr"
+)
+
+func TestClient_APIError(t *testing.T) {
+	tests := []struct {
+		name             string
+		givenStatusCode  int
+		givenBody        string
+		expectedErrors   []error
+		unexpectedErrors []error
+		expectedAPIError oxr.APIError
+	}{
+		{
+			name:            "given invalid app id payload, expect ErrInvalidAppID",
+			givenStatusCode: http.StatusUnauthorized,
+			givenBody:       errorPayload(401, "invalid_app_id", "Invalid App ID provided."),
+			expectedErrors:  []error{oxr.ErrInvalidAppID, oxr.ErrBadResponse},
+			unexpectedErrors: []error{
+				oxr.ErrMissingAppID, oxr.ErrQuotaExceeded, oxr.ErrAccessRestricted,
+			},
+			expectedAPIError: oxr.APIError{
+				StatusCode:  http.StatusUnauthorized,
+				Status:      401,
+				Message:     "invalid_app_id",
+				Description: "Invalid App ID provided.",
+			},
+		},
+		{
+			name:             "given missing app id payload, expect ErrMissingAppID",
+			givenStatusCode:  http.StatusUnauthorized,
+			givenBody:        errorPayload(401, "missing_app_id", "No App ID provided."),
+			expectedErrors:   []error{oxr.ErrMissingAppID, oxr.ErrBadResponse},
+			unexpectedErrors: []error{oxr.ErrInvalidAppID},
+			expectedAPIError: oxr.APIError{
+				StatusCode:  http.StatusUnauthorized,
+				Status:      401,
+				Message:     "missing_app_id",
+				Description: "No App ID provided.",
+			},
+		},
+		{
+			name:             "given not allowed payload, expect ErrNotAllowed",
+			givenStatusCode:  http.StatusForbidden,
+			givenBody:        errorPayload(403, "not_allowed", "Changing the API `base` currency is available for Developer plans."),
+			expectedErrors:   []error{oxr.ErrNotAllowed, oxr.ErrBadResponse},
+			unexpectedErrors: []error{oxr.ErrAccessRestricted},
+			expectedAPIError: oxr.APIError{
+				StatusCode:  http.StatusForbidden,
+				Status:      403,
+				Message:     "not_allowed",
+				Description: "Changing the API `base` currency is available for Developer plans.",
+			},
+		},
+		{
+			name:             "given access restricted payload, expect ErrAccessRestricted",
+			givenStatusCode:  http.StatusForbidden,
+			givenBody:        errorPayload(403, "access_restricted", "Access restricted for this App ID."),
+			expectedErrors:   []error{oxr.ErrAccessRestricted, oxr.ErrBadResponse},
+			unexpectedErrors: []error{oxr.ErrQuotaExceeded},
+			expectedAPIError: oxr.APIError{
+				StatusCode:  http.StatusForbidden,
+				Status:      403,
+				Message:     "access_restricted",
+				Description: "Access restricted for this App ID.",
+			},
+		},
+		{
+			name:            "given access restricted for over-use payload, expect ErrQuotaExceeded",
+			givenStatusCode: http.StatusTooManyRequests,
+			givenBody:       errorPayload(429, "access_restricted", "Access restricted until 2022-04-01 (reason: too_many_requests)."),
+			expectedErrors:  []error{oxr.ErrQuotaExceeded, oxr.ErrAccessRestricted, oxr.ErrBadResponse},
+			expectedAPIError: oxr.APIError{
+				StatusCode:  http.StatusTooManyRequests,
+				Status:      429,
+				Message:     "access_restricted",
+				Description: "Access restricted until 2022-04-01 (reason: too_many_requests).",
+			},
+		},
+		{
+			name:            "given invalid base payload, expect ErrInvalidBase",
+			givenStatusCode: http.StatusBadRequest,
+			givenBody:       errorPayload(400, "invalid_base", "Client requested rates for an unsupported base currency."),
+			expectedErrors:  []error{oxr.ErrInvalidBase, oxr.ErrBadResponse},
+			expectedAPIError: oxr.APIError{
+				StatusCode:  http.StatusBadRequest,
+				Status:      400,
+				Message:     "invalid_base",
+				Description: "Client requested rates for an unsupported base currency.",
+			},
+		},
+		{
+			name:            "given not found payload, expect ErrNotFound",
+			givenStatusCode: http.StatusNotFound,
+			givenBody:       errorPayload(404, "not_found", "Client requested a non-existent resource/route."),
+			expectedErrors:  []error{oxr.ErrNotFound, oxr.ErrBadResponse},
+			expectedAPIError: oxr.APIError{
+				StatusCode:  http.StatusNotFound,
+				Status:      404,
+				Message:     "not_found",
+				Description: "Client requested a non-existent resource/route.",
+			},
+		},
+		{
+			name:            "given non json body, expect only ErrBadResponse",
+			givenStatusCode: http.StatusBadGateway,
+			givenBody:       "<html>Bad Gateway</html>",
+			expectedErrors:  []error{oxr.ErrBadResponse},
+			unexpectedErrors: []error{
+				oxr.ErrNotFound, oxr.ErrMissingAppID, oxr.ErrInvalidAppID, oxr.ErrNotAllowed,
+				oxr.ErrAccessRestricted, oxr.ErrInvalidBase, oxr.ErrQuotaExceeded,
+			},
+			expectedAPIError: oxr.APIError{
+				StatusCode: http.StatusBadGateway,
+				Status:     http.StatusBadGateway,
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := oxr.New(oxr.WithAppID("test"), oxr.WithDoer(&mockDoer{
+				GivenResponse: &http.Response{
+					Status:     http.StatusText(test.givenStatusCode),
+					StatusCode: test.givenStatusCode,
+					Body:       io.NopCloser(strings.NewReader(test.givenBody)),
+				},
+			}))
+
+			_, err := c.Latest(context.Background())
+			if err == nil {
+				t.Fatal("expected error, got nil")
+			}
+
+			for _, expected := range test.expectedErrors {
+				if !errors.Is(err, expected) {
+					t.Fatalf("expected %v to be %v", err, expected)
+				}
+			}
+
+			for _, unexpected := range test.unexpectedErrors {
+				if errors.Is(err, unexpected) {
+					t.Fatalf("expected %v not to be %v", err, unexpected)
+				}
+			}
+
+			var apiErr *oxr.APIError
+			if !errors.As(err, &apiErr) {
+				t.Fatalf("expected %T, got %T", apiErr, err)
+			}
+
+			if !cmp.Equal(*apiErr, test.expectedAPIError) {
+				t.Fatal(cmp.Diff(*apiErr, test.expectedAPIError))
+			}
+		})
+	}
+}
+
+func errorPayload(status int, message, description string) string {
+	return `{
+  "error": true,
+  "status": ` + strconv.Itoa(status) + `,
+  "message": "` + message + `",
+  "description": "` + description + `"
+}`
+}
@@ -0,0 +1,140 @@
+package oxr
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// flightGroup collapses concurrent calls with the same key into a single call, sharing its outcome with every caller.
+type flightGroup struct {
+	mu      sync.Mutex
+	flights map[string]*flight
+}
+
+// flight is a call in progress.
+type flight struct {
+	done    chan struct{}
+	cancel  context.CancelFunc
+	waiters int
+	result  flightResult
+	err     error
+	// panicked reports whether the call panicked, in which case panicValue is raised again in every waiting caller.
+	panicked   bool
+	panicValue interface{}
+}
+
+// flightResult is the outcome of a successful flight.
+type flightResult struct {
+	// value is the decoded response, which only the caller that started the flight may use without copying.
+	value interface{}
+	body  []byte
+	meta  ResponseMeta
+}
+
+// flightFunc performs a call, returning its decoded response along with the body it was decoded from.
+type flightFunc func(ctx context.Context, meta *ResponseMeta) (interface{}, []byte, error)
+
+func newFlightGroup() *flightGroup {
+	return &flightGroup{flights: make(map[string]*flight)}
+}
+
+// do performs fn once for every concurrent caller with the same key, reporting whether the outcome was shared with an
+// earlier caller. fn is given a context which is only cancelled once every waiting caller has given up, so that one
+// caller's context being done does not fail the others. The meta of a caller which gives up is left empty. Should fn
+// panic, the panic is raised again in each caller still waiting rather than crashing the process from the goroutine.
+func (g *flightGroup) do(ctx context.Context, key string, fn flightFunc) (flightResult, bool, error) {
+	g.mu.Lock()
+
+	f, shared := g.flights[key]
+	if !shared {
+		flightCtx, cancel := context.WithCancel(detachedContext{parent: ctx})
+		f = &flight{done: make(chan struct{}), cancel: cancel}
+		g.flights[key] = f
+
+		go g.run(flightCtx, key, f, fn)
+	}
+	f.waiters++
+
+	g.mu.Unlock()
+
+	select {
+	case <-f.done:
+		if f.panicked {
+			panic(f.panicValue)
+		}
+
+		return f.result, shared, f.err
+	case <-ctx.Done():
+		if g.leave(key, f) {
+			// The last caller waits for the cancelled flight to unwind, as it would had the call not been shared, so
+			// that any quota it reserved has been refunded by the time the caller returns.
+			<-f.done
+			if f.panicked {
+				panic(f.panicValue)
+			}
+		}
+
+		return flightResult{}, shared, ctx.Err()
+	}
+}
+
+func (g *flightGroup) run(ctx context.Context, key string, f *flight, fn flightFunc) {
+	defer func() {
+		if p := recover(); p != nil {
+			f.panicked, f.panicValue = true, p
+		}
+
+		f.cancel()
+
+		g.mu.Lock()
+		if g.flights[key] == f {
+			delete(g.flights, key)
+		}
+		g.mu.Unlock()
+
+		close(f.done)
+	}()
+
+	f.result.value, f.result.body, f.err = fn(ctx, &f.result.meta)
+}
+
+// leave removes a caller from the flight, cancelling it and reporting true once no callers remain. An abandoned flight
+// is forgotten immediately, so that later callers start a new one rather than join a call which is being cancelled.
+func (g *flightGroup) leave(key string, f *flight) bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	f.waiters--
+	if f.waiters > 0 {
+		return false
+	}
+
+	f.cancel()
+	if g.flights[key] == f {
+		delete(g.flights, key)
+	}
+
+	return true
+}
+
+// detachedContext keeps the values of its parent, such as trace spans, but is never done.
+type detachedContext struct {
+	parent context.Context
+}
+
+func (detachedContext) Deadline() (time.Time, bool) {
+	return time.Time{}, false
+}
+
+func (detachedContext) Done() <-chan struct{} {
+	return nil
+}
+
+func (detachedContext) Err() error {
+	return nil
+}
+
+func (d detachedContext) Value(key interface{}) interface{} {
+	return d.parent.Value(key)
+}
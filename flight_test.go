@@ -0,0 +1,207 @@
+package oxr_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jamieaitken/oxr"
+)
+
+// joinDelay is how long a test allows concurrent callers to join a request in flight.
+const joinDelay = 50 * time.Millisecond
+
+func TestClient_Deduplication(t *testing.T) {
+	tests := []struct {
+		name             string
+		givenOpts        [][]oxr.LatestOption
+		expectedRequests int32
+	}{
+		{
+			name: "given identical calls, expect one request",
+			givenOpts: [][]oxr.LatestOption{
+				{oxr.LatestForDestinationCurrencies([]string{"GBP", "EUR"})},
+				{oxr.LatestForDestinationCurrencies([]string{"EUR", "GBP"})},
+				{oxr.LatestForDestinationCurrencies([]string{"eur", "gbp"})},
+			},
+			expectedRequests: 1,
+		},
+		{
+			name: "given different calls, expect request for each",
+			givenOpts: [][]oxr.LatestOption{
+				{oxr.LatestForDestinationCurrencies([]string{"GBP"})},
+				{oxr.LatestForDestinationCurrencies([]string{"EUR"})},
+			},
+			expectedRequests: 2,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var requests int32
+			release := make(chan struct{})
+
+			doer := oxr.DoerFunc(func(r *http.Request) (*http.Response, error) {
+				atomic.AddInt32(&requests, 1)
+				<-release
+
+				return (&sequenceDoer{GivenResults: []mockResult{{StatusCode: http.StatusOK, Body: successfulLatest()}}}).Do(r)
+			})
+
+			c := oxr.New(oxr.WithAppID("test"), oxr.WithDoer(doer))
+
+			results := make([]oxr.LatestRatesResponse, len(test.givenOpts))
+			errs := make([]error, len(test.givenOpts))
+
+			var wg sync.WaitGroup
+			for i, opts := range test.givenOpts {
+				wg.Add(1)
+				go func(i int, opts []oxr.LatestOption) {
+					defer wg.Done()
+					results[i], errs[i] = c.Latest(context.Background(), opts...)
+				}(i, opts)
+			}
+
+			time.Sleep(joinDelay)
+			close(release)
+			wg.Wait()
+
+			for i := range results {
+				if errs[i] != nil {
+					t.Fatal(errs[i])
+				}
+
+				if !cmp.Equal(results[i], results[0]) {
+					t.Fatal(cmp.Diff(results[i], results[0]))
+				}
+			}
+
+			if !cmp.Equal(atomic.LoadInt32(&requests), test.expectedRequests) {
+				t.Fatal(cmp.Diff(atomic.LoadInt32(&requests), test.expectedRequests))
+			}
+		})
+	}
+}
+
+func TestClient_Deduplication_Cancellation(t *testing.T) {
+	var requests int32
+	release := make(chan struct{})
+
+	doer := oxr.DoerFunc(func(r *http.Request) (*http.Response, error) {
+		atomic.AddInt32(&requests, 1)
+
+		select {
+		case <-release:
+		case <-r.Context().Done():
+			return nil, r.Context().Err()
+		}
+
+		return (&sequenceDoer{GivenResults: []mockResult{{StatusCode: http.StatusOK, Body: successfulLatest()}}}).Do(r)
+	})
+
+	c := oxr.New(oxr.WithAppID("test"), oxr.WithDoer(doer))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	canceledErr := make(chan error, 1)
+	go func() {
+		_, err := c.Latest(ctx)
+		canceledErr <- err
+	}()
+
+	var meta oxr.ResponseMeta
+	waitingErr := make(chan error, 1)
+	go func() {
+		time.Sleep(joinDelay / 2)
+		_, err := c.Latest(context.Background(), oxr.LatestWithMeta(&meta))
+		waitingErr <- err
+	}()
+
+	time.Sleep(joinDelay)
+	cancel()
+
+	if err := <-canceledErr; !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected %v, got %v", context.Canceled, err)
+	}
+
+	close(release)
+
+	if err := <-waitingErr; err != nil {
+		t.Fatal(err)
+	}
+
+	if !cmp.Equal(meta.Provenance, oxr.ProvenanceNetwork) {
+		t.Fatal(cmp.Diff(meta.Provenance, oxr.ProvenanceNetwork))
+	}
+
+	if !cmp.Equal(atomic.LoadInt32(&requests), int32(1)) {
+		t.Fatal(cmp.Diff(atomic.LoadInt32(&requests), int32(1)))
+	}
+}
+
+func TestClient_Deduplication_Abandoned(t *testing.T) {
+	canceled := make(chan struct{})
+
+	doer := oxr.DoerFunc(func(r *http.Request) (*http.Response, error) {
+		<-r.Context().Done()
+		close(canceled)
+
+		return nil, r.Context().Err()
+	})
+
+	c := oxr.New(oxr.WithAppID("test"), oxr.WithDoer(doer))
+
+	ctx, cancel := context.WithTimeout(context.Background(), joinDelay)
+	defer cancel()
+
+	_, err := c.Latest(ctx)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected %v, got %v", context.DeadlineExceeded, err)
+	}
+
+	select {
+	case <-canceled:
+	default:
+		t.Fatal("expected request to be cancelled once every caller gave up")
+	}
+}
+
+func TestClient_Deduplication_Panic(t *testing.T) {
+	release := make(chan struct{})
+
+	doer := oxr.DoerFunc(func(r *http.Request) (*http.Response, error) {
+		<-release
+		panic("doer panicked")
+	})
+
+	c := oxr.New(oxr.WithAppID("test"), oxr.WithDoer(doer))
+
+	recovered := make([]interface{}, 2)
+
+	var wg sync.WaitGroup
+	for i := range recovered {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			defer func() {
+				recovered[i] = recover()
+			}()
+
+			_, _ = c.Latest(context.Background())
+		}(i)
+	}
+
+	time.Sleep(joinDelay)
+	close(release)
+	wg.Wait()
+
+	expected := []interface{}{"doer panicked", "doer panicked"}
+	if !cmp.Equal(recovered, expected) {
+		t.Fatal(cmp.Diff(recovered, expected))
+	}
+}
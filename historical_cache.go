@@ -0,0 +1,75 @@
+package oxr
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// HistoricalDiskCache permanently stores Historical responses on the local filesystem. Only responses for fully elapsed
+// UTC days are stored, as the rates for those days can no longer change. Files are written atomically, so the directory
+// can be shared by concurrent processes.
+type HistoricalDiskCache struct {
+	files FileCache
+}
+
+// NewHistoricalDiskCache instantiates a HistoricalDiskCache, creating the given directory if it does not exist.
+func NewHistoricalDiskCache(dir string) (HistoricalDiskCache, error) {
+	files, err := NewFileCache(dir)
+	if err != nil {
+		return HistoricalDiskCache{}, err
+	}
+
+	return HistoricalDiskCache{files: files}, nil
+}
+
+func (h HistoricalDiskCache) get(ctx context.Context, p historicalParams) (HistoricalRatesResponse, bool) {
+	b, ok, err := h.files.Get(ctx, p.cacheKey())
+	if err != nil || !ok {
+		return HistoricalRatesResponse{}, false
+	}
+
+	var res HistoricalRatesResponse
+	err = json.Unmarshal(b, &res)
+	if err != nil {
+		return HistoricalRatesResponse{}, false
+	}
+
+	return res, true
+}
+
+func (h HistoricalDiskCache) set(ctx context.Context, p historicalParams, res HistoricalRatesResponse) error {
+	if !dayElapsed(p.date, time.Now()) {
+		return nil
+	}
+
+	b, err := json.Marshal(res)
+	if err != nil {
+		return err
+	}
+
+	return h.files.Set(ctx, p.cacheKey(), b, 0)
+}
+
+// cacheKey canonicalises the parameters which affect the payload of a Historical response.
+func (p historicalParams) cacheKey() string {
+	base := strings.ToUpper(strings.TrimSpace(p.baseCurrency))
+	if base == "" {
+		base = "USD"
+	}
+
+	return fmt.Sprintf("historical:date=%s&base=%s&symbols=%s&show_alternative=%t",
+		p.date.Format(timeFormat), base, canonicalSymbols(p.destinationCurrencies), p.showAlternative)
+}
+
+// dayElapsed reports whether the UTC day requested for the given date has fully elapsed.
+func dayElapsed(date, now time.Time) bool {
+	day, err := time.Parse(timeFormat, date.Format(timeFormat))
+	if err != nil {
+		return false
+	}
+
+	return !now.Before(day.AddDate(0, 0, 1))
+}
@@ -0,0 +1,138 @@
+package oxr_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jamieaitken/oxr"
+)
+
+func TestClient_Historical_DiskCache(t *testing.T) {
+	tests := []struct {
+		name          string
+		givenDate     time.Time
+		givenOpts     [][]oxr.HistoricalOption
+		expectedCalls int
+		expectedFiles int
+	}{
+		{
+			name:      "given elapsed day, expect subsequent clients served from disk",
+			givenDate: time.Date(2022, 3, 10, 0, 0, 0, 0, time.UTC),
+			givenOpts: [][]oxr.HistoricalOption{
+				{oxr.HistoricalForDestinationCurrencies([]string{"GBP", "EUR"})},
+				{oxr.HistoricalForDestinationCurrencies([]string{"EUR", "GBP"})},
+				{oxr.HistoricalForBaseCurrency("USD"), oxr.HistoricalForDestinationCurrencies([]string{"GBP", "EUR"})},
+			},
+			expectedCalls: 1,
+			expectedFiles: 1,
+		},
+		{
+			name:      "given differing symbols, expect separate entries",
+			givenDate: time.Date(2022, 3, 10, 0, 0, 0, 0, time.UTC),
+			givenOpts: [][]oxr.HistoricalOption{
+				{oxr.HistoricalForDestinationCurrencies([]string{"GBP"})},
+				{oxr.HistoricalForDestinationCurrencies([]string{"EUR"})},
+				{oxr.HistoricalForDestinationCurrencies([]string{"GBP"})},
+			},
+			expectedCalls: 2,
+			expectedFiles: 2,
+		},
+		{
+			name:      "given current day, expect response not stored",
+			givenDate: time.Now().UTC(),
+			givenOpts: [][]oxr.HistoricalOption{
+				{},
+				{},
+			},
+			expectedCalls: 2,
+			expectedFiles: 0,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			dir := t.TempDir()
+			doer := &sequenceDoer{GivenResults: []mockResult{
+				{StatusCode: 200, Body: successfulHistorical()},
+			}}
+
+			for _, opts := range test.givenOpts {
+				cache, err := oxr.NewHistoricalDiskCache(dir)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				c := oxr.New(oxr.WithAppID("test"), oxr.WithDoer(doer), oxr.WithHistoricalCache(cache))
+
+				actual, err := c.Historical(context.Background(), append(opts, oxr.HistoricalForDate(test.givenDate))...)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				expected := map[string]float64{"GBP": 0.76, "EUR": 0.93}
+				if !cmp.Equal(actual.Rates, expected) {
+					t.Fatal(cmp.Diff(actual.Rates, expected))
+				}
+			}
+
+			if !cmp.Equal(doer.Calls(), test.expectedCalls) {
+				t.Fatal(cmp.Diff(doer.Calls(), test.expectedCalls))
+			}
+
+			files, err := filepath.Glob(filepath.Join(dir, "*.json"))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !cmp.Equal(len(files), test.expectedFiles) {
+				t.Fatal(cmp.Diff(len(files), test.expectedFiles))
+			}
+		})
+	}
+}
+
+func TestClient_Historical_DiskCache_Corrupt(t *testing.T) {
+	dir := t.TempDir()
+	doer := &sequenceDoer{GivenResults: []mockResult{
+		{StatusCode: 200, Body: successfulHistorical()},
+	}}
+
+	cache, err := oxr.NewHistoricalDiskCache(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c := oxr.New(oxr.WithAppID("test"), oxr.WithDoer(doer), oxr.WithHistoricalCache(cache))
+	date := oxr.HistoricalForDate(time.Date(2022, 3, 10, 0, 0, 0, 0, time.UTC))
+
+	if _, err = c.Historical(context.Background(), date); err != nil {
+		t.Fatal(err)
+	}
+
+	files, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, file := range files {
+		if err = os.WriteFile(file, []byte(`{"rates":`), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	actual, err := c.Historical(context.Background(), date)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !cmp.Equal(doer.Calls(), 2) {
+		t.Fatal(cmp.Diff(doer.Calls(), 2))
+	}
+
+	if !cmp.Equal(actual.Timestamp, int64(1341936000)) {
+		t.Fatal(cmp.Diff(actual.Timestamp, int64(1341936000)))
+	}
+}
@@ -11,6 +11,11 @@ type historicalParams struct {
 	destinationCurrencies string
 	showAlternative       bool
 	prettyPrint           bool
+	meta                  *ResponseMeta
+
+	// currencyErr is the first currency given which is not in the registry, returned before any request is sent unless
+	// currency validation is disabled.
+	currencyErr error
 }
 
 // HistoricalOption allows the client to specify values for a historical request.
@@ -19,14 +24,16 @@ type HistoricalOption func(*historicalParams)
 // HistoricalForBaseCurrency sets the base currency for the historical request.
 func HistoricalForBaseCurrency(currency string) HistoricalOption {
 	return func(p *historicalParams) {
-		p.baseCurrency = currency
+		code, err := validCurrency(currency)
+		p.baseCurrency, p.currencyErr = code, firstErr(p.currencyErr, err)
 	}
 }
 
 // HistoricalForDestinationCurrencies sets the destination currency for the historical request.
 func HistoricalForDestinationCurrencies(currencies []string) HistoricalOption {
 	return func(p *historicalParams) {
-		p.destinationCurrencies = strings.Join(currencies, ",")
+		codes, err := validCurrencies(currencies)
+		p.destinationCurrencies, p.currencyErr = strings.Join(codes, ","), firstErr(p.currencyErr, err)
 	}
 }
 
@@ -50,3 +57,10 @@ func HistoricalWithPrettyPrint(active bool) HistoricalOption {
 		p.prettyPrint = active
 	}
 }
+
+// HistoricalWithMeta populates meta with how the result of the historical request was obtained.
+func HistoricalWithMeta(meta *ResponseMeta) HistoricalOption {
+	return func(p *historicalParams) {
+		p.meta = meta
+	}
+}
@@ -0,0 +1,178 @@
+package oxr
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+var updateFrequencyPattern = regexp.MustCompile(`^(\d+)[- ]?(minute|hour|day)s?$`)
+
+// CacheStats reports how effective a cache has been.
+type CacheStats struct {
+	Hits      uint64
+	Misses    uint64
+	Evictions uint64
+}
+
+// LatestCache is an in-memory cache of Latest responses. Entries expire once the plan's update interval has elapsed
+// since the timestamp of the response, as no newer rates can be available before then.
+type LatestCache struct {
+	updateInterval time.Duration
+
+	mu      sync.Mutex
+	entries map[string]LatestRatesResponse
+	stats   CacheStats
+}
+
+// NewLatestCache instantiates a LatestCache for a plan which updates rates at the given interval.
+func NewLatestCache(updateInterval time.Duration) *LatestCache {
+	return &LatestCache{
+		updateInterval: updateInterval,
+		entries:        make(map[string]LatestRatesResponse),
+	}
+}
+
+// Stats returns the hit, miss and eviction counters of the cache.
+func (l *LatestCache) Stats() CacheStats {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return l.stats
+}
+
+func (l *LatestCache) get(key string) (LatestRatesResponse, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	res, ok := l.entries[key]
+	if !ok {
+		l.stats.Misses++
+		return LatestRatesResponse{}, false
+	}
+
+	if !time.Now().Before(l.expiry(res)) {
+		delete(l.entries, key)
+		l.stats.Evictions++
+		l.stats.Misses++
+		return LatestRatesResponse{}, false
+	}
+
+	l.stats.Hits++
+
+	return copyLatest(res), true
+}
+
+func (l *LatestCache) set(key string, res LatestRatesResponse) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	for k, v := range l.entries {
+		if !now.Before(l.expiry(v)) {
+			delete(l.entries, k)
+			l.stats.Evictions++
+		}
+	}
+
+	if !now.Before(l.expiry(res)) {
+		return
+	}
+
+	l.entries[key] = copyLatest(res)
+}
+
+func (l *LatestCache) expiry(res LatestRatesResponse) time.Time {
+	return time.Unix(res.Timestamp, 0).Add(l.updateInterval)
+}
+
+func copyLatest(res LatestRatesResponse) LatestRatesResponse {
+	rates := make(map[string]float64, len(res.Rates))
+	for k, v := range res.Rates {
+		rates[k] = v
+	}
+
+	res.Rates = rates
+
+	if res.ExactRates != nil {
+		exact := make(map[string]Decimal, len(res.ExactRates))
+		for k, v := range res.ExactRates {
+			exact[k] = v
+		}
+
+		res.ExactRates = exact
+	}
+
+	return res
+}
+
+// cacheKey canonicalises the parameters which affect the payload of a Latest response.
+func (p latestParams) cacheKey() string {
+	base := strings.ToUpper(strings.TrimSpace(p.baseCurrency))
+	if base == "" {
+		base = "USD"
+	}
+
+	return fmt.Sprintf("base=%s&symbols=%s&show_alternative=%t",
+		base, canonicalSymbols(p.destinationCurrencies), p.showAlternative)
+}
+
+// canonicalSymbols upper cases, de-duplicates and sorts a comma separated list of currencies.
+func canonicalSymbols(symbols string) string {
+	seen := make(map[string]struct{})
+	var canonical []string
+
+	for _, symbol := range strings.Split(symbols, ",") {
+		symbol = strings.ToUpper(strings.TrimSpace(symbol))
+		if symbol == "" {
+			continue
+		}
+
+		if _, ok := seen[symbol]; ok {
+			continue
+		}
+
+		seen[symbol] = struct{}{}
+		canonical = append(canonical, symbol)
+	}
+
+	sort.Strings(canonical)
+
+	return strings.Join(canonical, ",")
+}
+
+// UpdateInterval parses the UpdateFrequency of the plan, such as "30-minute" or "hourly", into a time.Duration.
+func (p UsageDataPlan) UpdateInterval() (time.Duration, error) {
+	frequency := strings.ToLower(strings.TrimSpace(p.UpdateFrequency))
+
+	switch frequency {
+	case "hourly":
+		return time.Hour, nil
+	case "daily":
+		return 24 * time.Hour, nil
+	}
+
+	matches := updateFrequencyPattern.FindStringSubmatch(frequency)
+	if matches == nil {
+		return 0, fmt.Errorf("unrecognised update frequency: %q", p.UpdateFrequency)
+	}
+
+	n, err := strconv.Atoi(matches[1])
+	if err != nil {
+		return 0, err
+	}
+
+	unit := time.Minute
+	switch matches[2] {
+	case "hour":
+		unit = time.Hour
+	case "day":
+		unit = 24 * time.Hour
+	}
+
+	return time.Duration(n) * unit, nil
+}
@@ -0,0 +1,170 @@
+package oxr_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jamieaitken/oxr"
+)
+
+func TestClient_Latest_Cache(t *testing.T) {
+	tests := []struct {
+		name              string
+		givenTimestamp    time.Time
+		givenInterval     time.Duration
+		givenRequests     [][]oxr.LatestOption
+		expectedCalls     int
+		expectedStats     oxr.CacheStats
+		expectedLastRates map[string]float64
+	}{
+		{
+			name:           "given identical requests within update interval, expect cached response served",
+			givenTimestamp: time.Now(),
+			givenInterval:  time.Hour,
+			givenRequests: [][]oxr.LatestOption{
+				{oxr.LatestForDestinationCurrencies([]string{"GBP", "EUR"})},
+				{oxr.LatestForDestinationCurrencies([]string{"eur", "GBP", "GBP"}), oxr.LatestWithPrettyPrint(true)},
+				{oxr.LatestForBaseCurrency("USD"), oxr.LatestForDestinationCurrencies([]string{"EUR", "GBP"})},
+			},
+			expectedCalls:     1,
+			expectedStats:     oxr.CacheStats{Hits: 2, Misses: 1},
+			expectedLastRates: map[string]float64{"GBP": 0.76, "EUR": 0.93},
+		},
+		{
+			name:           "given differing parameters, expect separate entries",
+			givenTimestamp: time.Now(),
+			givenInterval:  time.Hour,
+			givenRequests: [][]oxr.LatestOption{
+				{oxr.LatestForDestinationCurrencies([]string{"GBP"})},
+				{oxr.LatestForDestinationCurrencies([]string{"GBP"}), oxr.LatestWithAlternatives(true)},
+				{oxr.LatestForBaseCurrency("EUR"), oxr.LatestForDestinationCurrencies([]string{"GBP"})},
+			},
+			expectedCalls:     3,
+			expectedStats:     oxr.CacheStats{Misses: 3},
+			expectedLastRates: map[string]float64{"GBP": 0.76, "EUR": 0.93},
+		},
+		{
+			name:           "given response older than update interval, expect entry not served",
+			givenTimestamp: time.Now().Add(-2 * time.Hour),
+			givenInterval:  time.Hour,
+			givenRequests: [][]oxr.LatestOption{
+				{},
+				{},
+			},
+			expectedCalls:     2,
+			expectedStats:     oxr.CacheStats{Misses: 2},
+			expectedLastRates: map[string]float64{"GBP": 0.76, "EUR": 0.93},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			doer := &sequenceDoer{GivenResults: []mockResult{
+				{StatusCode: 200, Body: latestAt(test.givenTimestamp)},
+			}}
+			cache := oxr.NewLatestCache(test.givenInterval)
+			c := oxr.New(oxr.WithAppID("test"), oxr.WithDoer(doer), oxr.WithLatestCache(cache))
+
+			var actual oxr.LatestRatesResponse
+			for _, opts := range test.givenRequests {
+				var err error
+				actual, err = c.Latest(context.Background(), opts...)
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			if !cmp.Equal(doer.Calls(), test.expectedCalls) {
+				t.Fatal(cmp.Diff(doer.Calls(), test.expectedCalls))
+			}
+
+			if !cmp.Equal(cache.Stats(), test.expectedStats) {
+				t.Fatal(cmp.Diff(cache.Stats(), test.expectedStats))
+			}
+
+			if !cmp.Equal(actual.Rates, test.expectedLastRates) {
+				t.Fatal(cmp.Diff(actual.Rates, test.expectedLastRates))
+			}
+		})
+	}
+}
+
+func TestLatestCache_Eviction(t *testing.T) {
+	doer := &sequenceDoer{GivenResults: []mockResult{
+		{StatusCode: 200, Body: latestAt(time.Now())},
+	}}
+	cache := oxr.NewLatestCache(1500 * time.Millisecond)
+	c := oxr.New(oxr.WithAppID("test"), oxr.WithDoer(doer), oxr.WithLatestCache(cache))
+
+	if _, err := c.Latest(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	time.Sleep(1600 * time.Millisecond)
+
+	if _, err := c.Latest(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := oxr.CacheStats{Misses: 2, Evictions: 1}
+	if !cmp.Equal(cache.Stats(), expected) {
+		t.Fatal(cmp.Diff(cache.Stats(), expected))
+	}
+}
+
+func TestUsageDataPlan_UpdateInterval(t *testing.T) {
+	tests := []struct {
+		name             string
+		givenFrequency   string
+		expectedInterval time.Duration
+		expectedErr      bool
+	}{
+		{
+			name:             "given minute frequency, expect minutes",
+			givenFrequency:   "30-minute",
+			expectedInterval: 30 * time.Minute,
+		},
+		{
+			name:             "given hourly frequency, expect one hour",
+			givenFrequency:   "hourly",
+			expectedInterval: time.Hour,
+		},
+		{
+			name:             "given day frequency, expect days",
+			givenFrequency:   "1-day",
+			expectedInterval: 24 * time.Hour,
+		},
+		{
+			name:           "given unknown frequency, expect error",
+			givenFrequency: "whenever",
+			expectedErr:    true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			actual, err := oxr.UsageDataPlan{UpdateFrequency: test.givenFrequency}.UpdateInterval()
+			if (err != nil) != test.expectedErr {
+				t.Fatalf("expected error %v, got %v", test.expectedErr, err)
+			}
+
+			if !cmp.Equal(actual, test.expectedInterval) {
+				t.Fatal(cmp.Diff(actual, test.expectedInterval))
+			}
+		})
+	}
+}
+
+func latestAt(timestamp time.Time) string {
+	return fmt.Sprintf(`{
+  "disclaimer": "Usage subject to terms: https://openexchangerates.org/terms",
+  "license": "https://openexchangerates.org/license",
+  "timestamp": %d,
+  "base": "USD",
+  "rates": {
+    "GBP": 0.76,
+    "EUR": 0.93
+  }
+}`, timestamp.Unix())
+}
@@ -9,6 +9,11 @@ type latestParams struct {
 	destinationCurrencies string
 	showAlternative       bool
 	prettyPrint           bool
+	meta                  *ResponseMeta
+
+	// currencyErr is the first currency given which is not in the registry, returned before any request is sent unless
+	// currency validation is disabled.
+	currencyErr error
 }
 
 // LatestOption allows the client to specify values for a latest request.
@@ -17,14 +22,16 @@ type LatestOption func(params *latestParams)
 // LatestForBaseCurrency sets the base currency.
 func LatestForBaseCurrency(currency string) LatestOption {
 	return func(p *latestParams) {
-		p.baseCurrency = currency
+		code, err := validCurrency(currency)
+		p.baseCurrency, p.currencyErr = code, firstErr(p.currencyErr, err)
 	}
 }
 
 // LatestForDestinationCurrencies sets the destination currencies to be included in the response.
 func LatestForDestinationCurrencies(currencies []string) LatestOption {
 	return func(p *latestParams) {
-		p.destinationCurrencies = strings.Join(currencies, ",")
+		codes, err := validCurrencies(currencies)
+		p.destinationCurrencies, p.currencyErr = strings.Join(codes, ","), firstErr(p.currencyErr, err)
 	}
 }
 
@@ -41,3 +48,10 @@ func LatestWithPrettyPrint(active bool) LatestOption {
 		p.prettyPrint = active
 	}
 }
+
+// LatestWithMeta populates meta with how the result of the latest request was obtained.
+func LatestWithMeta(meta *ResponseMeta) LatestOption {
+	return func(p *latestParams) {
+		p.meta = meta
+	}
+}
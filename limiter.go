@@ -0,0 +1,223 @@
+package oxr
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+)
+
+const (
+	defaultLimiterRefreshInterval = 5 * time.Minute
+	defaultLimiterBurst           = 1
+
+	// limiterRefreshBackoff is how long to wait before retrying a failed refresh, unless the refresh interval is shorter.
+	limiterRefreshBackoff = 10 * time.Second
+)
+
+// LimitMode decides what a QuotaLimiter does with a request made faster than the sustainable rate.
+type LimitMode int
+
+// Available limit modes.
+const (
+	// LimitWait delays the request until it may be sent, or its context is done.
+	LimitWait LimitMode = iota
+	// LimitReject fails the request with ErrRateLimited.
+	LimitReject
+)
+
+var (
+	ErrRateLimited     = errors.New("request exceeds the sustainable rate for the remaining quota")
+	ErrBudgetExhausted = fmt.Errorf("request budget for the billing period is spent: %w", ErrQuotaExceeded)
+)
+
+// Budget describes the requests remaining in the billing period, as last known by a QuotaLimiter.
+type Budget struct {
+	// Unlimited is true when the plan has no quota, in which case requests are not limited.
+	Unlimited bool
+	Quota     int
+	Remaining int
+	ResetAt   time.Time
+	// Rate is the number of requests per second which spends the remaining quota evenly until ResetAt.
+	Rate        float64
+	RefreshedAt time.Time
+}
+
+// QuotaLimiter paces the requests made by a Client so that its quota lasts for the rest of the billing period. Usage is
+// refreshed from OXR periodically, and the remaining quota spread evenly until the period resets. Requests made faster
+// than this are delayed or rejected depending on the LimitMode, and once the quota is spent every request is rejected
+// with ErrBudgetExhausted. Should Usage be unavailable, requests are not limited until it is.
+type QuotaLimiter struct {
+	refreshInterval time.Duration
+	mode            LimitMode
+	burst           float64
+
+	mu         sync.Mutex
+	budget     Budget
+	known      bool
+	tokens     float64
+	last       time.Time
+	refreshing bool
+	retryAt    time.Time
+}
+
+// QuotaLimiterOption allows a QuotaLimiter to be modified.
+type QuotaLimiterOption func(*QuotaLimiter)
+
+// NewQuotaLimiter instantiates a QuotaLimiter. Use it with WithQuotaLimiter.
+func NewQuotaLimiter(opts ...QuotaLimiterOption) *QuotaLimiter {
+	l := &QuotaLimiter{
+		refreshInterval: defaultLimiterRefreshInterval,
+		mode:            LimitWait,
+		burst:           defaultLimiterBurst,
+	}
+
+	for _, opt := range opts {
+		opt(l)
+	}
+
+	l.tokens = l.burst
+
+	return l
+}
+
+// QuotaLimiterWithRefreshInterval sets how often Usage is refreshed from OXR. Defaults to five minutes.
+func QuotaLimiterWithRefreshInterval(interval time.Duration) QuotaLimiterOption {
+	return func(l *QuotaLimiter) {
+		l.refreshInterval = interval
+	}
+}
+
+// QuotaLimiterWithMode sets what happens to requests made faster than the sustainable rate. Defaults to LimitWait.
+func QuotaLimiterWithMode(mode LimitMode) QuotaLimiterOption {
+	return func(l *QuotaLimiter) {
+		l.mode = mode
+	}
+}
+
+// QuotaLimiterWithBurst sets how many requests may be made at once after a period of inactivity. Defaults to one.
+func QuotaLimiterWithBurst(burst int) QuotaLimiterOption {
+	return func(l *QuotaLimiter) {
+		l.burst = float64(burst)
+	}
+}
+
+// Budget returns the requests remaining in the billing period, as last known.
+func (l *QuotaLimiter) Budget() Budget {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return l.budget
+}
+
+// wait blocks until a request may be sent, refreshing usage using the Client when it is stale.
+func (l *QuotaLimiter) wait(ctx context.Context, c Client) error {
+	l.refresh(ctx, c)
+
+	l.mu.Lock()
+
+	now := time.Now()
+	if !l.known || l.budget.Unlimited {
+		l.mu.Unlock()
+		return nil
+	}
+
+	if l.budget.Remaining <= 0 {
+		l.mu.Unlock()
+		return ErrBudgetExhausted
+	}
+
+	l.fill(now)
+
+	var delay time.Duration
+	if l.tokens < 1 {
+		if l.mode == LimitReject || l.budget.Rate <= 0 {
+			l.mu.Unlock()
+			return ErrRateLimited
+		}
+
+		delay = time.Duration((1 - l.tokens) / l.budget.Rate * float64(time.Second))
+	}
+
+	l.tokens--
+	l.budget.Remaining--
+	l.mu.Unlock()
+
+	if delay == 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		l.mu.Lock()
+		l.tokens++
+		l.budget.Remaining++
+		l.mu.Unlock()
+
+		return ctx.Err()
+	}
+}
+
+// refresh replaces the budget with the latest usage should it be stale. Only one refresh runs at a time, and requests
+// made meanwhile use the previous budget rather than wait for it. Should usage be unavailable, the previous budget is
+// kept and the refresh retried after a short backoff.
+func (l *QuotaLimiter) refresh(ctx context.Context, c Client) {
+	l.mu.Lock()
+
+	now := time.Now()
+	if l.refreshing || now.Sub(l.budget.RefreshedAt) < l.refreshInterval || now.Before(l.retryAt) {
+		l.mu.Unlock()
+		return
+	}
+
+	l.refreshing = true
+	l.mu.Unlock()
+
+	usage, err := c.Usage(ctx)
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.refreshing = false
+	if err != nil {
+		l.retryAt = now.Add(minDuration(limiterRefreshBackoff, l.refreshInterval))
+		return
+	}
+
+	data := usage.Data.Usage
+	resetAt := billingReset(now, data.DaysRemaining)
+
+	l.known = true
+	l.budget = Budget{
+		Unlimited:   data.RequestsQuota <= 0,
+		Quota:       data.RequestsQuota,
+		Remaining:   data.RequestsRemaining,
+		ResetAt:     resetAt,
+		Rate:        float64(data.RequestsRemaining) / math.Max(resetAt.Sub(now).Seconds(), 1),
+		RefreshedAt: now,
+	}
+}
+
+// fill adds the tokens accrued at the sustainable rate since the last request.
+func (l *QuotaLimiter) fill(now time.Time) {
+	if !l.last.IsZero() {
+		l.tokens = math.Min(l.burst, l.tokens+now.Sub(l.last).Seconds()*l.budget.Rate)
+	}
+
+	l.last = now
+}
+
+func minDuration(a, b time.Duration) time.Duration {
+	if a < b {
+		return a
+	}
+
+	return b
+}
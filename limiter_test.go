@@ -0,0 +1,239 @@
+package oxr_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jamieaitken/oxr"
+)
+
+func TestQuotaLimiter(t *testing.T) {
+	tests := []struct {
+		name              string
+		givenUsage        string
+		givenOpts         []oxr.QuotaLimiterOption
+		givenCalls        int
+		givenTimeout      time.Duration
+		expectedErrs      []error
+		expectedRequests  int
+		expectedRemaining int
+		expectedUnlimited bool
+	}{
+		{
+			name:              "given unlimited plan, expect no limiting",
+			givenUsage:        usagePayload(0, -1, 14),
+			givenOpts:         []oxr.QuotaLimiterOption{oxr.QuotaLimiterWithMode(oxr.LimitReject)},
+			givenCalls:        3,
+			expectedErrs:      []error{nil, nil, nil},
+			expectedRequests:  3,
+			expectedRemaining: -1,
+			expectedUnlimited: true,
+		},
+		{
+			name:              "given burst spent in reject mode, expect rate limited",
+			givenUsage:        usagePayload(1000, 500, 14),
+			givenOpts:         []oxr.QuotaLimiterOption{oxr.QuotaLimiterWithMode(oxr.LimitReject), oxr.QuotaLimiterWithBurst(2)},
+			givenCalls:        3,
+			expectedErrs:      []error{nil, nil, oxr.ErrRateLimited},
+			expectedRequests:  2,
+			expectedRemaining: 498,
+		},
+		{
+			name:              "given budget spent, expect budget exhausted",
+			givenUsage:        usagePayload(1000, 2, 14),
+			givenOpts:         []oxr.QuotaLimiterOption{oxr.QuotaLimiterWithBurst(5)},
+			givenCalls:        3,
+			expectedErrs:      []error{nil, nil, oxr.ErrQuotaExceeded},
+			expectedRequests:  2,
+			expectedRemaining: 0,
+		},
+		{
+			name:              "given burst spent in wait mode, expect context error once deadline passes",
+			givenUsage:        usagePayload(1000, 500, 14),
+			givenCalls:        2,
+			givenTimeout:      20 * time.Millisecond,
+			expectedErrs:      []error{nil, context.DeadlineExceeded},
+			expectedRequests:  1,
+			expectedRemaining: 499,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var requests int
+
+			doer := oxr.DoerFunc(func(r *http.Request) (*http.Response, error) {
+				body := successfulLatest()
+				if strings.HasSuffix(r.URL.Path, "usage.json") {
+					body = test.givenUsage
+				} else {
+					requests++
+				}
+
+				return (&sequenceDoer{GivenResults: []mockResult{{StatusCode: http.StatusOK, Body: body}}}).Do(r)
+			})
+
+			limiter := oxr.NewQuotaLimiter(test.givenOpts...)
+			c := oxr.New(oxr.WithAppID("test"), oxr.WithDoer(doer), oxr.WithQuotaLimiter(limiter))
+
+			for i := 0; i < test.givenCalls; i++ {
+				ctx := context.Background()
+				if test.givenTimeout > 0 {
+					var cancel context.CancelFunc
+					ctx, cancel = context.WithTimeout(ctx, test.givenTimeout)
+					defer cancel()
+				}
+
+				_, err := c.Latest(ctx)
+				if !errors.Is(err, test.expectedErrs[i]) {
+					t.Fatalf("call %d: expected %v, got %v", i, test.expectedErrs[i], err)
+				}
+			}
+
+			if !cmp.Equal(requests, test.expectedRequests) {
+				t.Fatal(cmp.Diff(requests, test.expectedRequests))
+			}
+
+			budget := limiter.Budget()
+			if !cmp.Equal(budget.Remaining, test.expectedRemaining) {
+				t.Fatal(cmp.Diff(budget.Remaining, test.expectedRemaining))
+			}
+
+			if !cmp.Equal(budget.Unlimited, test.expectedUnlimited) {
+				t.Fatal(cmp.Diff(budget.Unlimited, test.expectedUnlimited))
+			}
+		})
+	}
+}
+
+func TestQuotaLimiter_Budget(t *testing.T) {
+	doer := &sequenceDoer{GivenResults: []mockResult{
+		{StatusCode: http.StatusOK, Body: usagePayload(1000, 500, 14)},
+		{StatusCode: http.StatusOK, Body: successfulLatest()},
+	}}
+
+	limiter := oxr.NewQuotaLimiter()
+	c := oxr.New(oxr.WithAppID("test"), oxr.WithDoer(doer), oxr.WithQuotaLimiter(limiter))
+
+	if _, err := c.Latest(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	budget := limiter.Budget()
+
+	now := time.Now().UTC()
+	expectedResetAt := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC).AddDate(0, 0, 15)
+	if !cmp.Equal(budget.ResetAt, expectedResetAt) {
+		t.Fatal(cmp.Diff(budget.ResetAt, expectedResetAt))
+	}
+
+	expectedRate := 500 / time.Until(expectedResetAt).Seconds()
+	if budget.Rate < expectedRate*0.99 || budget.Rate > expectedRate*1.01 {
+		t.Fatalf("expected rate of about %v, got %v", expectedRate, budget.Rate)
+	}
+}
+
+func usagePayload(quota, remaining, daysRemaining int) string {
+	return fmt.Sprintf(`{
+  "status": 200,
+  "data": {
+    "app_id": "YOUR_APP_ID",
+    "status": "active",
+    "plan": {
+      "name": "Developer",
+      "quota": "%d requests/month",
+      "update_frequency": "3600s"
+    },
+    "usage": {
+      "requests": %d,
+      "requests_quota": %d,
+      "requests_remaining": %d,
+      "days_elapsed": 16,
+      "days_remaining": %d,
+      "daily_average": 1
+    }
+  }
+}`, quota, quota-remaining, quota, remaining, daysRemaining)
+}
+
+func TestQuotaLimiter_RefreshFailure(t *testing.T) {
+	doer := &sequenceDoer{GivenResults: []mockResult{
+		{StatusCode: http.StatusServiceUnavailable},
+		{StatusCode: http.StatusOK, Body: successfulLatest()},
+		{StatusCode: http.StatusOK, Body: usagePayload(1000, 500, 14)},
+		{StatusCode: http.StatusOK, Body: successfulLatest()},
+	}}
+
+	limiter := oxr.NewQuotaLimiter(oxr.QuotaLimiterWithRefreshInterval(time.Millisecond))
+	c := oxr.New(oxr.WithAppID("test"), oxr.WithDoer(doer), oxr.WithQuotaLimiter(limiter))
+
+	if _, err := c.Latest(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	if refreshedAt := limiter.Budget().RefreshedAt; !refreshedAt.IsZero() {
+		t.Fatalf("expected failed refresh not to be recorded, got %v", refreshedAt)
+	}
+
+	time.Sleep(2 * time.Millisecond)
+
+	if _, err := c.Latest(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	if !cmp.Equal(limiter.Budget().Remaining, 499) {
+		t.Fatal(cmp.Diff(limiter.Budget().Remaining, 499))
+	}
+}
+
+func TestQuotaLimiter_RefreshOutsideLock(t *testing.T) {
+	started, release := make(chan struct{}), make(chan struct{})
+
+	doer := oxr.DoerFunc(func(r *http.Request) (*http.Response, error) {
+		body := successfulLatest()
+		if strings.HasSuffix(r.URL.Path, "usage.json") {
+			close(started)
+			<-release
+			body = usagePayload(1000, 500, 14)
+		}
+
+		return (&sequenceDoer{GivenResults: []mockResult{{StatusCode: http.StatusOK, Body: body}}}).Do(r)
+	})
+
+	limiter := oxr.NewQuotaLimiter()
+	c := oxr.New(oxr.WithAppID("test"), oxr.WithDoer(doer), oxr.WithQuotaLimiter(limiter))
+
+	refreshed := make(chan error, 1)
+	go func() {
+		_, err := c.Latest(context.Background())
+		refreshed <- err
+	}()
+
+	<-started
+
+	budget := make(chan oxr.Budget, 1)
+	go func() {
+		budget <- limiter.Budget()
+	}()
+
+	select {
+	case <-budget:
+	case <-time.After(time.Second):
+		t.Fatal("expected budget while usage is being refreshed")
+	}
+
+	if _, err := c.Latest(context.Background(), oxr.LatestForDestinationCurrencies([]string{"GBP"})); err != nil {
+		t.Fatal(err)
+	}
+
+	close(release)
+
+	if err := <-refreshed; err != nil {
+		t.Fatal(err)
+	}
+}
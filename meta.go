@@ -0,0 +1,48 @@
+package oxr
+
+import (
+	"net/http"
+	"time"
+)
+
+// Provenance describes where the result of a call came from.
+type Provenance string
+
+// Available provenances.
+const (
+	// ProvenanceNetwork is a result decoded from a response sent by OXR.
+	ProvenanceNetwork Provenance = "network"
+	// ProvenanceCache is a result served from a cache without sending a request.
+	ProvenanceCache Provenance = "cache"
+	// ProvenanceRevalidated is a result previously received, which OXR confirmed is unchanged with 304 Not Modified.
+	ProvenanceRevalidated Provenance = "revalidated"
+	// ProvenanceDerived is a result calculated locally from another, such as rates rebased by WithLocalRebase.
+	ProvenanceDerived Provenance = "derived"
+)
+
+// ResponseMeta describes how the result of a call was obtained. It is populated using the Meta option of each
+// endpoint, for example LatestWithMeta. Results served from a cache only report their Provenance, whereas derived
+// results keep the rest of the metadata of the response they were derived from.
+type ResponseMeta struct {
+	Provenance Provenance
+	StatusCode int
+	Header     http.Header
+	// URL is the final URL of the request, after any redirects, with the App ID redacted.
+	URL       string
+	Duration  time.Duration
+	BytesRead int64
+}
+
+// set populates m, should the caller have asked for it.
+func (m *ResponseMeta) set(meta ResponseMeta) {
+	if m != nil {
+		*m = meta
+	}
+}
+
+// derive marks m as calculated locally, should the caller have asked for it.
+func (m *ResponseMeta) derive() {
+	if m != nil {
+		m.Provenance = ProvenanceDerived
+	}
+}
@@ -0,0 +1,129 @@
+package oxr_test
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/jamieaitken/oxr"
+)
+
+func TestLatestWithMeta(t *testing.T) {
+	header := http.Header{
+		"Date": []string{"Wed, 16 Mar 2022 18:00:00 GMT"},
+		"Etag": []string{`"abc"`},
+	}
+
+	tests := []struct {
+		name            string
+		givenResults    []mockResult
+		givenClientOpts []oxr.ClientOption
+		givenCalls      int
+		expected        oxr.ResponseMeta
+	}{
+		{
+			name:         "given response from network, expect network meta",
+			givenResults: []mockResult{{StatusCode: http.StatusOK, Header: header, Body: successfulLatest()}},
+			givenCalls:   1,
+			expected: oxr.ResponseMeta{
+				Provenance: oxr.ProvenanceNetwork,
+				StatusCode: http.StatusOK,
+				Header:     header,
+				URL:        "https://openexchangerates.org/api/latest.json?app_id=REDACTED&prettyprint=false&show_alternative=false",
+				BytesRead:  int64(len(successfulLatest())),
+			},
+		},
+		{
+			name: "given not modified response, expect revalidated meta",
+			givenResults: []mockResult{
+				{StatusCode: http.StatusOK, Header: header, Body: successfulLatest()},
+				{StatusCode: http.StatusNotModified, Header: header},
+			},
+			givenCalls: 2,
+			expected: oxr.ResponseMeta{
+				Provenance: oxr.ProvenanceRevalidated,
+				StatusCode: http.StatusNotModified,
+				Header:     header,
+				URL:        "https://openexchangerates.org/api/latest.json?app_id=REDACTED&prettyprint=false&show_alternative=false",
+			},
+		},
+		{
+			name:         "given cached response, expect cache meta",
+			givenResults: []mockResult{{StatusCode: http.StatusOK, Body: strings.Replace(successfulLatest(), "1647453600", "4102444800", 1)}},
+			givenClientOpts: []oxr.ClientOption{
+				oxr.WithLatestCache(oxr.NewLatestCache(time.Hour)),
+			},
+			givenCalls: 2,
+			expected:   oxr.ResponseMeta{Provenance: oxr.ProvenanceCache},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			doer := &sequenceDoer{GivenResults: test.givenResults}
+			c := oxr.New(append([]oxr.ClientOption{oxr.WithAppID("test"), oxr.WithDoer(doer)}, test.givenClientOpts...)...)
+
+			var actual oxr.ResponseMeta
+			for i := 0; i < test.givenCalls; i++ {
+				_, err := c.Latest(context.Background(), oxr.LatestWithMeta(&actual))
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			if !cmp.Equal(actual, test.expected, cmpopts.IgnoreFields(oxr.ResponseMeta{}, "Duration")) {
+				t.Fatal(cmp.Diff(actual, test.expected, cmpopts.IgnoreFields(oxr.ResponseMeta{}, "Duration")))
+			}
+		})
+	}
+}
+
+func TestLatestWithMeta_Derived(t *testing.T) {
+	doer := oxr.DoerFunc(func(r *http.Request) (*http.Response, error) {
+		body := freePlanUsage()
+		if !strings.HasSuffix(r.URL.Path, "usage.json") {
+			body = successfulLatest()
+		}
+
+		return (&sequenceDoer{GivenResults: []mockResult{{StatusCode: http.StatusOK, Body: body}}}).Do(r)
+	})
+
+	c := oxr.New(oxr.WithAppID("test"), oxr.WithDoer(doer), oxr.WithLocalRebase())
+
+	var actual oxr.ResponseMeta
+	_, err := c.Latest(context.Background(), oxr.LatestForBaseCurrency(oxr.CurrencyGBP), oxr.LatestWithMeta(&actual))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !cmp.Equal(actual.Provenance, oxr.ProvenanceDerived) {
+		t.Fatal(cmp.Diff(actual.Provenance, oxr.ProvenanceDerived))
+	}
+
+	if !cmp.Equal(actual.StatusCode, http.StatusOK) {
+		t.Fatal(cmp.Diff(actual.StatusCode, http.StatusOK))
+	}
+}
+
+func TestConverter_ConvertWithMeta(t *testing.T) {
+	converter := oxr.NewLatestConverter(oxr.LatestRatesResponse{Base: "USD", Rates: map[string]float64{"GBP": 0.76}})
+
+	var actual oxr.ResponseMeta
+	_, err := converter.Convert(
+		oxr.ConvertWithValue(10),
+		oxr.ConvertForBaseCurrency(oxr.CurrencyUSD),
+		oxr.ConvertForDestinationCurrency(oxr.CurrencyGBP),
+		oxr.ConvertWithMeta(&actual),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := oxr.ResponseMeta{Provenance: oxr.ProvenanceDerived}
+	if !cmp.Equal(actual, expected) {
+		t.Fatal(cmp.Diff(actual, expected))
+	}
+}
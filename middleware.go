@@ -0,0 +1,132 @@
+package oxr
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// RequestIDHeader is the header RequestIDMiddleware sets on each request.
+const RequestIDHeader = "X-Request-Id"
+
+const redacted = "REDACTED"
+
+type requestIDKey struct{}
+
+// DoerFunc allows an ordinary function to be used as a Doer.
+type DoerFunc func(r *http.Request) (*http.Response, error)
+
+// Do implements Doer for DoerFunc.
+func (f DoerFunc) Do(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
+
+// Middleware wraps a Doer to add behaviour to every request, such as logging or retries.
+type Middleware func(Doer) Doer
+
+// Logger is satisfied by *log.Logger.
+type Logger interface {
+	Printf(format string, v ...interface{})
+}
+
+// chain wraps the Doer so that the first Middleware is the first to see each request.
+func chain(doer Doer, middleware []Middleware) Doer {
+	for i := len(middleware) - 1; i >= 0; i-- {
+		doer = middleware[i](doer)
+	}
+
+	return doer
+}
+
+// LoggingMiddleware logs the method, URL, outcome and duration of every request. The App ID is redacted from the URL and
+// any error.
+func LoggingMiddleware(logger Logger) Middleware {
+	return func(next Doer) Doer {
+		return DoerFunc(func(r *http.Request) (*http.Response, error) {
+			start := time.Now()
+			res, err := next.Do(r)
+			duration := time.Since(start)
+
+			if err != nil {
+				logger.Printf("oxr: %s %s failed after %v: %v", r.Method, redactURL(r.URL), duration, redactError(err, requestAppID(r)))
+				return res, err
+			}
+
+			logger.Printf("oxr: %s %s %d in %v", r.Method, redactURL(r.URL), res.StatusCode, duration)
+
+			return res, nil
+		})
+	}
+}
+
+// TimingMiddleware reports how long each request took, along with its outcome, to the given function.
+func TimingMiddleware(observe func(r *http.Request, res *http.Response, err error, duration time.Duration)) Middleware {
+	return func(next Doer) Doer {
+		return DoerFunc(func(r *http.Request) (*http.Response, error) {
+			start := time.Now()
+			res, err := next.Do(r)
+			observe(r, res, err, time.Since(start))
+
+			return res, err
+		})
+	}
+}
+
+// RequestIDMiddleware sets the RequestIDHeader of every request which does not already have one. The ID is taken from
+// the request context when set using ContextWithRequestID, otherwise one is generated.
+func RequestIDMiddleware() Middleware {
+	return func(next Doer) Doer {
+		return DoerFunc(func(r *http.Request) (*http.Response, error) {
+			if r.Header.Get(RequestIDHeader) != "" {
+				return next.Do(r)
+			}
+
+			id, ok := RequestIDFromContext(r.Context())
+			if !ok {
+				id = newRequestID()
+			}
+
+			r = r.Clone(r.Context())
+			r.Header.Set(RequestIDHeader, id)
+
+			return next.Do(r)
+		})
+	}
+}
+
+// ContextWithRequestID returns a context carrying the request ID used by RequestIDMiddleware.
+func ContextWithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+// RequestIDFromContext returns the request ID carried by the context, if any.
+func RequestIDFromContext(ctx context.Context) (string, bool) {
+	id, ok := ctx.Value(requestIDKey{}).(string)
+
+	return id, ok && id != ""
+}
+
+func newRequestID() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+
+	return hex.EncodeToString(b)
+}
+
+// redactURL returns the URL with any App ID replaced.
+func redactURL(u *url.URL) string {
+	q := u.Query()
+	if _, ok := q["app_id"]; !ok {
+		return u.String()
+	}
+
+	q.Set("app_id", redacted)
+
+	clone := *u
+	clone.RawQuery = q.Encode()
+
+	return clone.String()
+}
@@ -0,0 +1,166 @@
+package oxr_test
+
+import (
+	"bytes"
+	"context"
+	"log"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jamieaitken/oxr"
+)
+
+func TestWithMiddleware_Order(t *testing.T) {
+	var actual []string
+
+	record := func(name string) oxr.Middleware {
+		return func(next oxr.Doer) oxr.Doer {
+			return oxr.DoerFunc(func(r *http.Request) (*http.Response, error) {
+				actual = append(actual, name)
+				return next.Do(r)
+			})
+		}
+	}
+
+	doer := &sequenceDoer{GivenResults: []mockResult{{StatusCode: http.StatusOK, Body: successfulLatest()}}}
+	c := oxr.New(
+		oxr.WithMiddleware(record("first"), record("second")),
+		oxr.WithAppID("test"),
+		oxr.WithDoer(doer),
+		oxr.WithMiddleware(record("third")),
+	)
+
+	if _, err := c.Latest(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{"first", "second", "third"}
+	if !cmp.Equal(actual, expected) {
+		t.Fatal(cmp.Diff(actual, expected))
+	}
+}
+
+func TestLoggingMiddleware(t *testing.T) {
+	tests := []struct {
+		name             string
+		givenResults     []mockResult
+		expectedContains []string
+	}{
+		{
+			name:         "given successful response, expect status logged",
+			givenResults: []mockResult{{StatusCode: http.StatusOK, Body: successfulLatest()}},
+			expectedContains: []string{
+				"oxr: GET https://openexchangerates.org/api/latest.json?app_id=REDACTED&prettyprint=false&show_alternative=false 200 in",
+			},
+		},
+		{
+			name:         "given transport error, expect error logged",
+			givenResults: []mockResult{{Error: http.ErrHandlerTimeout}},
+			expectedContains: []string{
+				"oxr: GET https://openexchangerates.org/api/latest.json?app_id=REDACTED&prettyprint=false&show_alternative=false failed after",
+				http.ErrHandlerTimeout.Error(),
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			c := oxr.New(
+				oxr.WithAppID("secret"),
+				oxr.WithDoer(&sequenceDoer{GivenResults: test.givenResults}),
+				oxr.WithMiddleware(oxr.LoggingMiddleware(log.New(&buf, "", 0))),
+			)
+
+			_, _ = c.Latest(context.Background())
+
+			if strings.Contains(buf.String(), "secret") {
+				t.Fatalf("expected app id to be redacted: %s", buf.String())
+			}
+
+			for _, expected := range test.expectedContains {
+				if !strings.Contains(buf.String(), expected) {
+					t.Fatalf("expected %q to contain %q", buf.String(), expected)
+				}
+			}
+		})
+	}
+}
+
+func TestTimingMiddleware(t *testing.T) {
+	var (
+		actualStatus   int
+		actualDuration time.Duration
+	)
+
+	doer := oxr.DoerFunc(func(r *http.Request) (*http.Response, error) {
+		time.Sleep(5 * time.Millisecond)
+		return (&sequenceDoer{GivenResults: []mockResult{{StatusCode: http.StatusOK, Body: successfulLatest()}}}).Do(r)
+	})
+
+	c := oxr.New(
+		oxr.WithAppID("test"),
+		oxr.WithDoer(doer),
+		oxr.WithMiddleware(oxr.TimingMiddleware(func(r *http.Request, res *http.Response, err error, duration time.Duration) {
+			actualStatus = res.StatusCode
+			actualDuration = duration
+		})),
+	)
+
+	if _, err := c.Latest(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	if !cmp.Equal(actualStatus, http.StatusOK) {
+		t.Fatal(cmp.Diff(actualStatus, http.StatusOK))
+	}
+
+	if actualDuration < 5*time.Millisecond {
+		t.Fatalf("expected duration of at least 5ms, got %v", actualDuration)
+	}
+}
+
+func TestRequestIDMiddleware(t *testing.T) {
+	tests := []struct {
+		name       string
+		givenCtx   context.Context
+		expectedID func(id string) bool
+	}{
+		{
+			name:     "given request id in context, expect it to be sent",
+			givenCtx: oxr.ContextWithRequestID(context.Background(), "abc-123"),
+			expectedID: func(id string) bool {
+				return id == "abc-123"
+			},
+		},
+		{
+			name:     "given no request id, expect one generated",
+			givenCtx: context.Background(),
+			expectedID: func(id string) bool {
+				return len(id) == 32
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var actual string
+
+			doer := oxr.DoerFunc(func(r *http.Request) (*http.Response, error) {
+				actual = r.Header.Get(oxr.RequestIDHeader)
+				return (&sequenceDoer{GivenResults: []mockResult{{StatusCode: http.StatusOK, Body: successfulLatest()}}}).Do(r)
+			})
+
+			c := oxr.New(oxr.WithAppID("test"), oxr.WithDoer(doer), oxr.WithMiddleware(oxr.RequestIDMiddleware()))
+
+			if _, err := c.Latest(test.givenCtx); err != nil {
+				t.Fatal(err)
+			}
+
+			if !test.expectedID(actual) {
+				t.Fatalf("unexpected request id %q", actual)
+			}
+		})
+	}
+}
@@ -9,6 +9,10 @@ type LatestRatesResponse struct {
 	Timestamp  int64              `json:"timestamp"`
 	Base       string             `json:"base"`
 	Rates      map[string]float64 `json:"rates"`
+	// ExactRates holds the same rates as Rates, decoded exactly rather than through float64.
+	ExactRates map[string]Decimal `json:"-"`
+	// Derived is true when the rates were derived locally, rather than received from OXR.
+	Derived bool `json:"-"`
 }
 
 // ConversionResponse is the response of a Conversion request.
@@ -18,6 +22,8 @@ type ConversionResponse struct {
 	Request    ConversionRequest `json:"request"`
 	Meta       ConversionMeta    `json:"meta"`
 	Response   float64           `json:"response"`
+	// ExactResponse holds the same value as Response, decoded exactly rather than through float64.
+	ExactResponse Decimal `json:"-"`
 }
 
 type ConversionRequest struct {
@@ -30,6 +36,8 @@ type ConversionRequest struct {
 type ConversionMeta struct {
 	Timestamp int64   `json:"timestamp"`
 	Rate      float64 `json:"rate"`
+	// ExactRate holds the same rate as Rate, decoded exactly rather than through float64.
+	ExactRate Decimal `json:"-"`
 }
 
 // CurrenciesResponse is the response of a Currencies request.
@@ -44,6 +52,10 @@ type HistoricalRatesResponse struct {
 	Timestamp  int64              `json:"timestamp"`
 	Base       string             `json:"base"`
 	Rates      map[string]float64 `json:"rates"`
+	// ExactRates holds the same rates as Rates, decoded exactly rather than through float64.
+	ExactRates map[string]Decimal `json:"-"`
+	// Derived is true when the rates were derived locally, rather than received from OXR.
+	Derived bool `json:"-"`
 }
 
 // OHLCResponse is the response of a OHLC request.
@@ -54,6 +66,8 @@ type OHLCResponse struct {
 	EndTime    time.Time           `json:"end_time"`
 	Base       string              `json:"base"`
 	Rates      map[string]OHLCRate `json:"rates"`
+	// ExactRates holds the same rates as Rates, decoded exactly rather than through float64.
+	ExactRates map[string]OHLCExactRate `json:"-"`
 }
 
 type OHLCRate struct {
@@ -64,6 +78,15 @@ type OHLCRate struct {
 	Average float64 `json:"average"`
 }
 
+// OHLCExactRate is an OHLCRate decoded exactly.
+type OHLCExactRate struct {
+	Open    Decimal `json:"open"`
+	High    Decimal `json:"high"`
+	Low     Decimal `json:"low"`
+	Close   Decimal `json:"close"`
+	Average Decimal `json:"average"`
+}
+
 // TimeSeriesResponse is the response of a TimeSeries request.
 type TimeSeriesResponse struct {
 	Disclaimer string                        `json:"disclaimer"`
@@ -72,6 +95,8 @@ type TimeSeriesResponse struct {
 	EndDate    string                        `json:"end_date"`
 	Base       string                        `json:"base"`
 	Rates      map[string]map[string]float64 `json:"rates"`
+	// ExactRates holds the same rates as Rates, decoded exactly rather than through float64.
+	ExactRates map[string]map[string]Decimal `json:"-"`
 }
 
 // UsageResponse is the response of a Usage request.
@@ -0,0 +1,63 @@
+package oxr
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Money is an exact amount of a currency.
+type Money struct {
+	Amount   Decimal
+	Currency string
+}
+
+// NewMoney instantiates Money for the amount of the currency.
+func NewMoney(amount Decimal, currency string) Money {
+	return Money{Amount: amount, Currency: strings.ToUpper(currency)}
+}
+
+// ParseMoney instantiates Money, parsing the amount exactly.
+func ParseMoney(amount, currency string) (Money, error) {
+	d, err := ParseDecimal(amount)
+	if err != nil {
+		return Money{}, err
+	}
+
+	return NewMoney(d, currency), nil
+}
+
+// Add returns the sum of m and other, which must be of the same currency.
+func (m Money) Add(other Money) (Money, error) {
+	if m.Currency != other.Currency {
+		return Money{}, fmt.Errorf("adding %s to %s: %w", other.Currency, m.Currency, ErrCurrencyMismatch)
+	}
+
+	return Money{Amount: m.Amount.Add(other.Amount), Currency: m.Currency}, nil
+}
+
+// Sub returns the difference of m and other, which must be of the same currency.
+func (m Money) Sub(other Money) (Money, error) {
+	if m.Currency != other.Currency {
+		return Money{}, fmt.Errorf("subtracting %s from %s: %w", other.Currency, m.Currency, ErrCurrencyMismatch)
+	}
+
+	return Money{Amount: m.Amount.Sub(other.Amount), Currency: m.Currency}, nil
+}
+
+// Round returns m rounded as the Rounding decides for its currency, usually to its minor units.
+func (m Money) Round(rounding Rounding) Money {
+	r := rounding.For(m.Currency)
+
+	return Money{Amount: m.Amount.Round(r.Places, r.Policy), Currency: m.Currency}
+}
+
+// Convert multiplies m by the rate at which one unit of its currency converts into the to currency, rounding the
+// result as the Rounding decides for the to currency.
+func (m Money) Convert(to string, rate Decimal, rounding Rounding) Money {
+	return NewMoney(m.Amount.Mul(rate), to).Round(rounding)
+}
+
+// String formats m as its amount followed by its currency, for example "123.45 GBP".
+func (m Money) String() string {
+	return m.Amount.String() + " " + m.Currency
+}
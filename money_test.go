@@ -0,0 +1,119 @@
+package oxr_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jamieaitken/oxr"
+)
+
+func TestMoney_Add(t *testing.T) {
+	tests := []struct {
+		name        string
+		givenA      oxr.Money
+		givenB      oxr.Money
+		expected    string
+		expectedErr error
+	}{
+		{
+			name:     "given same currency, expect exact sum",
+			givenA:   mustParseMoney(t, "0.10", "gbp"),
+			givenB:   mustParseMoney(t, "0.20", "GBP"),
+			expected: "0.30 GBP",
+		},
+		{
+			name:        "given different currencies, expect error",
+			givenA:      mustParseMoney(t, "0.10", "GBP"),
+			givenB:      mustParseMoney(t, "0.20", "EUR"),
+			expectedErr: oxr.ErrCurrencyMismatch,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			actual, err := test.givenA.Add(test.givenB)
+			if !errors.Is(err, test.expectedErr) {
+				t.Fatalf("expected %v, got %v", test.expectedErr, err)
+			}
+
+			if err != nil {
+				return
+			}
+
+			if !cmp.Equal(actual.String(), test.expected) {
+				t.Fatal(cmp.Diff(actual.String(), test.expected))
+			}
+		})
+	}
+}
+
+func TestMoney_Convert(t *testing.T) {
+	actual := mustParseMoney(t, "19999.95", "USD").Convert("GBP", oxr.MustParseDecimal("0.764018"), oxr.Rounding{})
+
+	expected := "15280.32 GBP"
+	if !cmp.Equal(actual.String(), expected) {
+		t.Fatal(cmp.Diff(actual.String(), expected))
+	}
+}
+
+func TestConverter_ConvertMoney(t *testing.T) {
+	var snapshot oxr.LatestRatesResponse
+	err := snapshot.UnmarshalJSON([]byte(`{"timestamp": 1647453600, "base": "USD", "rates": {"GBP": 0.8, "EUR": 0.9, "KWD": 0.4}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name        string
+		givenMoney  oxr.Money
+		givenTo     string
+		expected    string
+		expectedErr error
+	}{
+		{
+			name:       "given base to currency, expect exact multiplication",
+			givenMoney: mustParseMoney(t, "10.01", "USD"),
+			givenTo:    "KWD",
+			expected:   "4.004 KWD",
+		},
+		{
+			name:       "given cross currencies, expect single rounding",
+			givenMoney: mustParseMoney(t, "1.00", "GBP"),
+			givenTo:    "EUR",
+			expected:   "1.13 EUR",
+		},
+		{
+			name:        "given currency missing from snapshot, expect error",
+			givenMoney:  mustParseMoney(t, "1.00", "GBP"),
+			givenTo:     "KRW",
+			expectedErr: oxr.ErrMissingRate,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			actual, err := oxr.NewLatestConverter(snapshot).ConvertMoney(test.givenMoney, test.givenTo)
+			if !errors.Is(err, test.expectedErr) {
+				t.Fatalf("expected %v, got %v", test.expectedErr, err)
+			}
+
+			if err != nil {
+				return
+			}
+
+			if !cmp.Equal(actual.String(), test.expected) {
+				t.Fatal(cmp.Diff(actual.String(), test.expected))
+			}
+		})
+	}
+}
+
+func mustParseMoney(t *testing.T, amount, currency string) oxr.Money {
+	t.Helper()
+
+	m, err := oxr.ParseMoney(amount, currency)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return m
+}
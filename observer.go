@@ -0,0 +1,74 @@
+package oxr
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Error classes reported by ErrorClass.
+const (
+	ErrorClassNone             = "none"
+	ErrorClassCanceled         = "canceled"
+	ErrorClassDeadlineExceeded = "deadline_exceeded"
+	ErrorClassTransport        = "transport"
+)
+
+// Observer is notified of every request the Client sends to OXR, allowing metrics and traces to be recorded.
+type Observer interface {
+	// OnRequestStart is called before a request is sent.
+	OnRequestStart(ctx context.Context, endpoint Endpoint)
+	// OnRequestEnd is called once a response has been read, or the request has failed.
+	OnRequestEnd(ctx context.Context, end RequestEnd)
+	// OnDecodeFailure is called when a successful response could not be decoded.
+	OnDecodeFailure(ctx context.Context, endpoint Endpoint, err error)
+}
+
+// RequestEnd describes the outcome of a request.
+type RequestEnd struct {
+	Endpoint Endpoint
+	// KeyIndex is the position of the App ID which served the request within those given to WithAppIDs.
+	KeyIndex int
+	// StatusCode is zero when no response was received.
+	StatusCode int
+	Duration   time.Duration
+	BytesRead  int64
+	Err        error
+}
+
+// NopObserver implements Observer by doing nothing, so it can be embedded by types which only observe some events.
+type NopObserver struct{}
+
+// OnRequestStart implements Observer for NopObserver.
+func (NopObserver) OnRequestStart(context.Context, Endpoint) {}
+
+// OnRequestEnd implements Observer for NopObserver.
+func (NopObserver) OnRequestEnd(context.Context, RequestEnd) {}
+
+// OnDecodeFailure implements Observer for NopObserver.
+func (NopObserver) OnDecodeFailure(context.Context, Endpoint, error) {}
+
+// ErrorClass summarises an error returned by the Client into a low cardinality label. API errors are classified by
+// their OXR message, or their status code when no message was given.
+func ErrorClass(err error) string {
+	if err == nil {
+		return ErrorClassNone
+	}
+
+	var apiErr *APIError
+	switch {
+	case errors.As(err, &apiErr):
+		if apiErr.Message != "" {
+			return apiErr.Message
+		}
+
+		return fmt.Sprintf("status_%d", apiErr.StatusCode)
+	case errors.Is(err, context.Canceled):
+		return ErrorClassCanceled
+	case errors.Is(err, context.DeadlineExceeded):
+		return ErrorClassDeadlineExceeded
+	}
+
+	return ErrorClassTransport
+}
@@ -0,0 +1,144 @@
+package oxr_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jamieaitken/oxr"
+)
+
+func TestClient_Observer(t *testing.T) {
+	tests := []struct {
+		name           string
+		givenResults   []mockResult
+		expectedEvents []string
+		expectedEnd    oxr.RequestEnd
+		expectedClass  string
+	}{
+		{
+			name:           "given successful response, expect start and end observed",
+			givenResults:   []mockResult{{StatusCode: http.StatusOK, Body: successfulUsage()}},
+			expectedEvents: []string{"start:usage", "end:usage"},
+			expectedEnd: oxr.RequestEnd{
+				Endpoint:   oxr.EndpointUsage,
+				StatusCode: http.StatusOK,
+				BytesRead:  int64(len(successfulUsage())),
+			},
+			expectedClass: oxr.ErrorClassNone,
+		},
+		{
+			name: "given api error, expect error class of message",
+			givenResults: []mockResult{{
+				StatusCode: http.StatusUnauthorized,
+				Body:       errorPayload(401, "invalid_app_id", "Invalid App ID provided."),
+			}},
+			expectedEvents: []string{"start:usage", "end:usage"},
+			expectedEnd: oxr.RequestEnd{
+				Endpoint:   oxr.EndpointUsage,
+				StatusCode: http.StatusUnauthorized,
+				BytesRead:  int64(len(errorPayload(401, "invalid_app_id", "Invalid App ID provided."))),
+			},
+			expectedClass: "invalid_app_id",
+		},
+		{
+			name:           "given transport error, expect transport error class",
+			givenResults:   []mockResult{{Error: http.ErrHandlerTimeout}},
+			expectedEvents: []string{"start:usage", "end:usage"},
+			expectedEnd: oxr.RequestEnd{
+				Endpoint: oxr.EndpointUsage,
+			},
+			expectedClass: oxr.ErrorClassTransport,
+		},
+		{
+			name:           "given undecodable response, expect decode failure observed",
+			givenResults:   []mockResult{{StatusCode: http.StatusOK, Body: "{"}},
+			expectedEvents: []string{"start:usage", "end:usage", "decode:usage"},
+			expectedEnd: oxr.RequestEnd{
+				Endpoint:   oxr.EndpointUsage,
+				StatusCode: http.StatusOK,
+				BytesRead:  1,
+			},
+			expectedClass: oxr.ErrorClassNone,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			observer := &recordingObserver{}
+			c := oxr.New(
+				oxr.WithAppID("test"),
+				oxr.WithDoer(&sequenceDoer{GivenResults: test.givenResults}),
+				oxr.WithObserver(observer),
+			)
+
+			_, _ = c.Usage(context.Background())
+
+			if !cmp.Equal(observer.events, test.expectedEvents) {
+				t.Fatal(cmp.Diff(observer.events, test.expectedEvents))
+			}
+
+			actualClass := oxr.ErrorClass(observer.end.Err)
+			if !cmp.Equal(actualClass, test.expectedClass) {
+				t.Fatal(cmp.Diff(actualClass, test.expectedClass))
+			}
+
+			observer.end.Err = nil
+			observer.end.Duration = 0
+			if !cmp.Equal(observer.end, test.expectedEnd) {
+				t.Fatal(cmp.Diff(observer.end, test.expectedEnd))
+			}
+		})
+	}
+}
+
+func TestErrorClass(t *testing.T) {
+	tests := []struct {
+		name     string
+		given    error
+		expected string
+	}{
+		{name: "given nil, expect none", given: nil, expected: oxr.ErrorClassNone},
+		{name: "given cancellation, expect canceled", given: context.Canceled, expected: oxr.ErrorClassCanceled},
+		{name: "given deadline, expect deadline exceeded", given: context.DeadlineExceeded, expected: oxr.ErrorClassDeadlineExceeded},
+		{name: "given api error without message, expect status", given: &oxr.APIError{StatusCode: 502}, expected: "status_502"},
+		{name: "given other error, expect transport", given: errors.New("connection reset"), expected: oxr.ErrorClassTransport},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if !cmp.Equal(oxr.ErrorClass(test.given), test.expected) {
+				t.Fatal(cmp.Diff(oxr.ErrorClass(test.given), test.expected))
+			}
+		})
+	}
+}
+
+type recordingObserver struct {
+	mu     sync.Mutex
+	events []string
+	end    oxr.RequestEnd
+}
+
+func (r *recordingObserver) OnRequestStart(_ context.Context, endpoint oxr.Endpoint) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.events = append(r.events, "start:"+endpoint.String())
+}
+
+func (r *recordingObserver) OnRequestEnd(_ context.Context, end oxr.RequestEnd) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.events = append(r.events, "end:"+end.Endpoint.String())
+	r.end = end
+}
+
+func (r *recordingObserver) OnDecodeFailure(_ context.Context, endpoint oxr.Endpoint, _ error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.events = append(r.events, "decode:"+endpoint.String())
+}
@@ -24,6 +24,11 @@ type ohlcParams struct {
 	baseCurrency          string
 	destinationCurrencies string
 	prettyPrint           bool
+	meta                  *ResponseMeta
+
+	// currencyErr is the first currency given which is not in the registry, returned before any request is sent unless
+	// currency validation is disabled.
+	currencyErr error
 }
 
 type period string
@@ -60,13 +65,22 @@ func OHLCForPeriod(period period) OHLCOption {
 // OHLCForBaseCurrency sets the base currency.
 func OHLCForBaseCurrency(currency string) OHLCOption {
 	return func(p *ohlcParams) {
-		p.baseCurrency = currency
+		code, err := validCurrency(currency)
+		p.baseCurrency, p.currencyErr = code, firstErr(p.currencyErr, err)
 	}
 }
 
 // OHLCForDestinationCurrencies sets the destination currencies to be included in the response.
 func OHLCForDestinationCurrencies(destinationCurrencies []string) OHLCOption {
 	return func(p *ohlcParams) {
-		p.destinationCurrencies = strings.Join(destinationCurrencies, ",")
+		codes, err := validCurrencies(destinationCurrencies)
+		p.destinationCurrencies, p.currencyErr = strings.Join(codes, ","), firstErr(p.currencyErr, err)
+	}
+}
+
+// OHLCWithMeta populates meta with how the result of the OHLC request was obtained.
+func OHLCWithMeta(meta *ResponseMeta) OHLCOption {
+	return func(p *ohlcParams) {
+		p.meta = meta
 	}
 }
@@ -0,0 +1,123 @@
+// Package oxrprom provides an oxr.Observer which exposes Prometheus metrics.
+package oxrprom
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/jamieaitken/oxr"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const defaultNamespace = "oxr"
+
+// Collector is an oxr.Observer which records per endpoint request counts, latencies, error classes and bytes
+// downloaded. It implements prometheus.Collector, so it can be registered with any prometheus.Registerer.
+type Collector struct {
+	requests       *prometheus.CounterVec
+	inFlight       *prometheus.GaugeVec
+	duration       *prometheus.HistogramVec
+	bytes          *prometheus.CounterVec
+	decodeFailures *prometheus.CounterVec
+}
+
+type options struct {
+	namespace string
+	buckets   []float64
+}
+
+// Option allows a Collector to be modified.
+type Option func(*options)
+
+// WithNamespace sets the namespace of every metric. Defaults to oxr.
+func WithNamespace(namespace string) Option {
+	return func(o *options) {
+		o.namespace = namespace
+	}
+}
+
+// WithBuckets sets the buckets, in seconds, of the request duration histogram. Defaults to prometheus.DefBuckets.
+func WithBuckets(buckets []float64) Option {
+	return func(o *options) {
+		o.buckets = buckets
+	}
+}
+
+// NewCollector instantiates a Collector.
+func NewCollector(opts ...Option) *Collector {
+	o := options{
+		namespace: defaultNamespace,
+		buckets:   prometheus.DefBuckets,
+	}
+
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return &Collector{
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: o.namespace,
+			Name:      "requests_total",
+			Help:      "Requests sent to OXR, by endpoint, status code and error class.",
+		}, []string{"endpoint", "code", "error_class"}),
+		inFlight: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: o.namespace,
+			Name:      "requests_in_flight",
+			Help:      "Requests sent to OXR which have not yet completed, by endpoint.",
+		}, []string{"endpoint"}),
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: o.namespace,
+			Name:      "request_duration_seconds",
+			Help:      "Duration of requests sent to OXR, by endpoint.",
+			Buckets:   o.buckets,
+		}, []string{"endpoint"}),
+		bytes: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: o.namespace,
+			Name:      "response_bytes_total",
+			Help:      "Bytes downloaded from OXR, by endpoint.",
+		}, []string{"endpoint"}),
+		decodeFailures: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: o.namespace,
+			Name:      "decode_failures_total",
+			Help:      "Successful responses from OXR which could not be decoded, by endpoint.",
+		}, []string{"endpoint"}),
+	}
+}
+
+// Describe implements prometheus.Collector for Collector.
+func (c *Collector) Describe(ch chan<- *prometheus.Desc) {
+	c.requests.Describe(ch)
+	c.inFlight.Describe(ch)
+	c.duration.Describe(ch)
+	c.bytes.Describe(ch)
+	c.decodeFailures.Describe(ch)
+}
+
+// Collect implements prometheus.Collector for Collector.
+func (c *Collector) Collect(ch chan<- prometheus.Metric) {
+	c.requests.Collect(ch)
+	c.inFlight.Collect(ch)
+	c.duration.Collect(ch)
+	c.bytes.Collect(ch)
+	c.decodeFailures.Collect(ch)
+}
+
+// OnRequestStart implements oxr.Observer for Collector.
+func (c *Collector) OnRequestStart(_ context.Context, endpoint oxr.Endpoint) {
+	c.inFlight.WithLabelValues(endpoint.String()).Inc()
+}
+
+// OnRequestEnd implements oxr.Observer for Collector.
+func (c *Collector) OnRequestEnd(_ context.Context, end oxr.RequestEnd) {
+	endpoint := end.Endpoint.String()
+
+	c.inFlight.WithLabelValues(endpoint).Dec()
+	c.requests.WithLabelValues(endpoint, strconv.Itoa(end.StatusCode), oxr.ErrorClass(end.Err)).Inc()
+	c.duration.WithLabelValues(endpoint).Observe(end.Duration.Seconds())
+	c.bytes.WithLabelValues(endpoint).Add(float64(end.BytesRead))
+}
+
+// OnDecodeFailure implements oxr.Observer for Collector.
+func (c *Collector) OnDecodeFailure(_ context.Context, endpoint oxr.Endpoint, _ error) {
+	c.decodeFailures.WithLabelValues(endpoint.String()).Inc()
+}
@@ -0,0 +1,96 @@
+package oxrprom_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jamieaitken/oxr"
+	"github.com/jamieaitken/oxr/oxrprom"
+	"github.com/jamieaitken/oxr/oxrtest"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestCollector(t *testing.T) {
+	s := oxrtest.NewServer(oxrtest.WithFaults(oxrtest.CorruptBody().Times(1).OnPath("usage.json")))
+	defer s.Close()
+
+	collector := oxrprom.NewCollector(oxrprom.WithBuckets([]float64{1}))
+
+	registry := prometheus.NewRegistry()
+	if err := registry.Register(collector); err != nil {
+		t.Fatal(err)
+	}
+
+	c := s.Client(oxr.WithObserver(collector))
+	if _, err := c.Latest(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := c.Historical(context.Background(), oxr.HistoricalForDate(time.Now().AddDate(1, 0, 0))); err == nil {
+		t.Fatal("expected error for future date")
+	}
+
+	if _, err := c.Usage(context.Background()); err == nil {
+		t.Fatal("expected error for corrupt body")
+	}
+
+	expected := `
+# HELP oxr_decode_failures_total Successful responses from OXR which could not be decoded, by endpoint.
+# TYPE oxr_decode_failures_total counter
+oxr_decode_failures_total{endpoint="usage"} 1
+# HELP oxr_requests_in_flight Requests sent to OXR which have not yet completed, by endpoint.
+# TYPE oxr_requests_in_flight gauge
+oxr_requests_in_flight{endpoint="historical"} 0
+oxr_requests_in_flight{endpoint="latest"} 0
+oxr_requests_in_flight{endpoint="usage"} 0
+# HELP oxr_requests_total Requests sent to OXR, by endpoint, status code and error class.
+# TYPE oxr_requests_total counter
+oxr_requests_total{code="200",endpoint="latest",error_class="none"} 1
+oxr_requests_total{code="200",endpoint="usage",error_class="none"} 1
+oxr_requests_total{code="400",endpoint="historical",error_class="not_available"} 1
+`
+	err := testutil.CollectAndCompare(collector, strings.NewReader(expected),
+		"oxr_decode_failures_total", "oxr_requests_in_flight", "oxr_requests_total")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if count := testutil.CollectAndCount(collector, "oxr_request_duration_seconds"); count != 3 {
+		t.Fatalf("expected 3 duration series, got %d", count)
+	}
+
+	if bytes := responseBytes(t, registry, "latest"); bytes <= 0 {
+		t.Fatalf("expected bytes downloaded to be recorded, got %v", bytes)
+	}
+}
+
+// responseBytes returns the value of the response_bytes_total counter for the endpoint.
+func responseBytes(t *testing.T, registry *prometheus.Registry, endpoint string) float64 {
+	t.Helper()
+
+	families, err := registry.Gather()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, family := range families {
+		if family.GetName() != "oxr_response_bytes_total" {
+			continue
+		}
+
+		for _, metric := range family.GetMetric() {
+			for _, label := range metric.GetLabel() {
+				if label.GetName() == "endpoint" && label.GetValue() == endpoint {
+					return metric.GetCounter().GetValue()
+				}
+			}
+		}
+	}
+
+	t.Fatalf("no response_bytes_total for %s", endpoint)
+
+	return 0
+}
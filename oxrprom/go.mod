@@ -0,0 +1,24 @@
+module github.com/jamieaitken/oxr/oxrprom
+
+go 1.17
+
+require (
+	github.com/jamieaitken/oxr v0.0.0-20261016204156-825694e0c156
+	github.com/prometheus/client_golang v1.12.1
+)
+
+require (
+	github.com/beorn7/perks v1.0.1 // indirect
+	github.com/cespare/xxhash/v2 v2.1.2 // indirect
+	github.com/golang/protobuf v1.5.2 // indirect
+	github.com/matttproud/golang_protobuf_extensions v1.0.1 // indirect
+	github.com/prometheus/client_model v0.2.0 // indirect
+	github.com/prometheus/common v0.32.1 // indirect
+	github.com/prometheus/procfs v0.7.3 // indirect
+	golang.org/x/sys v0.0.0-20220114195835-da31bd327af9 // indirect
+	google.golang.org/protobuf v1.26.0 // indirect
+)
+
+// Builds against the oxr module in this repository during development. Consumers ignore this, resolving the version
+// required above instead.
+replace github.com/jamieaitken/oxr => ../
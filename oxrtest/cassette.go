@@ -0,0 +1,201 @@
+package oxrtest
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"sync"
+
+	"github.com/jamieaitken/oxr"
+)
+
+const redacted = "REDACTED"
+
+// ErrUnmatchedRequest is returned when replaying a request which was not recorded.
+var ErrUnmatchedRequest = errors.New("no recorded interaction matches request")
+
+// Interaction is a request and the response received for it.
+type Interaction struct {
+	Request  RecordedRequest  `json:"request"`
+	Response RecordedResponse `json:"response"`
+}
+
+// RecordedRequest is a request stored in a Cassette, with the App ID redacted from its URL.
+type RecordedRequest struct {
+	Method string `json:"method"`
+	URL    string `json:"url"`
+}
+
+// RecordedResponse is a response stored in a Cassette.
+type RecordedResponse struct {
+	StatusCode int         `json:"status_code"`
+	Header     http.Header `json:"header"`
+	Body       string      `json:"body"`
+}
+
+// Cassette is an oxr.Doer which either records real interactions to a file, or replays previously recorded
+// interactions without making any requests. Requests are matched on method, path and query, ignoring the App ID.
+type Cassette struct {
+	path   string
+	doer   oxr.Doer
+	record bool
+
+	mu           sync.Mutex
+	interactions []Interaction
+	replayed     []bool
+}
+
+// Record instantiates a Cassette which sends requests using the given Doer and records each interaction to the file at
+// path, replacing any previous recording.
+func Record(path string, doer oxr.Doer) *Cassette {
+	return &Cassette{
+		path:   path,
+		doer:   doer,
+		record: true,
+	}
+}
+
+// Replay instantiates a Cassette which serves the interactions recorded in the file at path. Each interaction is
+// replayed at most once, and requests which match no remaining interaction fail with ErrUnmatchedRequest.
+func Replay(path string) (*Cassette, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var interactions []Interaction
+	err = json.Unmarshal(b, &interactions)
+	if err != nil {
+		return nil, fmt.Errorf("decoding cassette %s: %w", path, err)
+	}
+
+	return &Cassette{
+		path:         path,
+		interactions: interactions,
+		replayed:     make([]bool, len(interactions)),
+	}, nil
+}
+
+// Do implements oxr.Doer for Cassette.
+func (c *Cassette) Do(r *http.Request) (*http.Response, error) {
+	if c.record {
+		return c.recordInteraction(r)
+	}
+
+	return c.replay(r)
+}
+
+// Interactions returns the interactions recorded, or loaded for replay.
+func (c *Cassette) Interactions() []Interaction {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return append([]Interaction(nil), c.interactions...)
+}
+
+func (c *Cassette) recordInteraction(r *http.Request) (*http.Response, error) {
+	res, err := c.doer.Do(r)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.interactions = append(c.interactions, Interaction{
+		Request: RecordedRequest{
+			Method: r.Method,
+			URL:    redactURL(r.URL),
+		},
+		Response: RecordedResponse{
+			StatusCode: res.StatusCode,
+			Header:     res.Header.Clone(),
+			Body:       string(body),
+		},
+	})
+
+	err = c.save()
+	if err != nil {
+		return nil, err
+	}
+
+	res.Body = io.NopCloser(bytes.NewReader(body))
+
+	return res, nil
+}
+
+func (c *Cassette) save() error {
+	b, err := json.MarshalIndent(c.interactions, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(c.path, b, 0o600)
+}
+
+func (c *Cassette) replay(r *http.Request) (*http.Response, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	key := matchKey(r.Method, r.URL)
+
+	for i, interaction := range c.interactions {
+		if c.replayed[i] {
+			continue
+		}
+
+		u, err := url.Parse(interaction.Request.URL)
+		if err != nil || matchKey(interaction.Request.Method, u) != key {
+			continue
+		}
+
+		c.replayed[i] = true
+
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", interaction.Response.StatusCode, http.StatusText(interaction.Response.StatusCode)),
+			StatusCode:    interaction.Response.StatusCode,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        interaction.Response.Header.Clone(),
+			Body:          io.NopCloser(bytes.NewBufferString(interaction.Response.Body)),
+			ContentLength: int64(len(interaction.Response.Body)),
+			Request:       r,
+		}, nil
+	}
+
+	return nil, fmt.Errorf("%s %s: %w", r.Method, redactURL(r.URL), ErrUnmatchedRequest)
+}
+
+// matchKey normalises a request so that recordings match regardless of App ID or the order of query parameters.
+func matchKey(method string, u *url.URL) string {
+	q := u.Query()
+	q.Del("app_id")
+
+	return fmt.Sprintf("%s %s?%s", method, u.Path, q.Encode())
+}
+
+// redactURL returns the URL with any App ID replaced.
+func redactURL(u *url.URL) string {
+	clone := *u
+	q := clone.Query()
+
+	if q.Has("app_id") {
+		q.Set("app_id", redacted)
+	}
+
+	clone.RawQuery = q.Encode()
+
+	return clone.String()
+}
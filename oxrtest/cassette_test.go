@@ -0,0 +1,91 @@
+package oxrtest_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jamieaitken/oxr"
+	"github.com/jamieaitken/oxr/oxrtest"
+)
+
+func TestCassette(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cassette.json")
+	date := oxr.HistoricalForDate(time.Date(2022, 3, 10, 0, 0, 0, 0, time.UTC))
+
+	s := oxrtest.NewServer(oxrtest.WithAccount("secret-app-id", oxrtest.UnlimitedPlan))
+
+	recorder := oxrtest.Record(path, s.Server.Client())
+	c := oxr.New(oxr.WithAppID("secret-app-id"), oxr.WithDoer(recorder), oxr.WithBaseURL(s.BaseURL()))
+
+	recordedLatest, err := c.Latest(context.Background(), oxr.LatestForDestinationCurrencies([]string{"GBP", "EUR"}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	recordedHistorical, err := c.Historical(context.Background(), date)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = oxr.New(oxr.WithAppID("unknown"), oxr.WithDoer(recorder), oxr.WithBaseURL(s.BaseURL())).Usage(context.Background())
+	if !errors.Is(err, oxr.ErrInvalidAppID) {
+		t.Fatalf("expected %v, got %v", oxr.ErrInvalidAppID, err)
+	}
+
+	s.Close()
+
+	b, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if strings.Contains(string(b), "secret-app-id") {
+		t.Fatal("expected app id to be redacted from cassette")
+	}
+
+	replayer, err := oxrtest.Replay(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c = oxr.New(oxr.WithAppID("another-app-id"), oxr.WithDoer(replayer), oxr.WithBaseURL(s.BaseURL()))
+
+	replayedHistorical, err := c.Historical(context.Background(), date)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !cmp.Equal(replayedHistorical, recordedHistorical) {
+		t.Fatal(cmp.Diff(replayedHistorical, recordedHistorical))
+	}
+
+	replayedLatest, err := c.Latest(context.Background(), oxr.LatestForDestinationCurrencies([]string{"GBP", "EUR"}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !cmp.Equal(replayedLatest, recordedLatest) {
+		t.Fatal(cmp.Diff(replayedLatest, recordedLatest))
+	}
+
+	_, err = c.Usage(context.Background())
+	if !errors.Is(err, oxr.ErrInvalidAppID) {
+		t.Fatalf("expected %v, got %v", oxr.ErrInvalidAppID, err)
+	}
+
+	_, err = c.Latest(context.Background(), oxr.LatestForDestinationCurrencies([]string{"JPY"}))
+	if !errors.Is(err, oxrtest.ErrUnmatchedRequest) {
+		t.Fatalf("expected %v, got %v", oxrtest.ErrUnmatchedRequest, err)
+	}
+
+	_, err = c.Historical(context.Background(), date)
+	if !errors.Is(err, oxrtest.ErrUnmatchedRequest) {
+		t.Fatalf("expected exhausted interaction to be unmatched, got %v", err)
+	}
+}
@@ -0,0 +1,85 @@
+package oxrtest
+
+import (
+	"hash/fnv"
+	"math"
+	"time"
+)
+
+const (
+	timeFormat = "2006-01-02"
+
+	// maxVariation is the largest daily deviation of a seeded rate from its reference rate.
+	maxVariation = 0.02
+)
+
+// Currency describes a currency served by the Server.
+type Currency struct {
+	Name string
+	// Rate is the number of units of the currency per US Dollar, from which the rates of each day are seeded.
+	Rate        float64
+	Alternative bool
+	Inactive    bool
+}
+
+// DefaultCurrencies are the currencies served by a Server unless WithCurrencies is used.
+func DefaultCurrencies() map[string]Currency {
+	return map[string]Currency{
+		"USD": {Name: "United States Dollar", Rate: 1},
+		"EUR": {Name: "Euro", Rate: 0.92},
+		"GBP": {Name: "British Pound Sterling", Rate: 0.79},
+		"JPY": {Name: "Japanese Yen", Rate: 149.5},
+		"CHF": {Name: "Swiss Franc", Rate: 0.88},
+		"AUD": {Name: "Australian Dollar", Rate: 1.52},
+		"CAD": {Name: "Canadian Dollar", Rate: 1.36},
+		"HKD": {Name: "Hong Kong Dollar", Rate: 7.82},
+		"CNY": {Name: "Chinese Yuan", Rate: 7.24},
+		"KWD": {Name: "Kuwaiti Dinar", Rate: 0.308},
+		"BTC": {Name: "Bitcoin", Rate: 0.000016, Alternative: true},
+		"VEF": {Name: "Venezuelan Bolívar Fuerte (Old)", Rate: 248487.6, Inactive: true},
+	}
+}
+
+// dataset holds the currencies and rates served by a Server. Rates for a day are derived deterministically from the seed,
+// unless they have been explicitly provided.
+type dataset struct {
+	seed       int64
+	currencies map[string]Currency
+	overrides  map[string]map[string]float64
+}
+
+// rates returns the rates of every currency per US Dollar for the given day.
+func (d dataset) rates(date time.Time) map[string]float64 {
+	day := date.UTC().Format(timeFormat)
+	rates := make(map[string]float64, len(d.currencies))
+
+	for code, currency := range d.currencies {
+		rates[code] = d.seeded(day, code, currency.Rate)
+	}
+
+	for code, rate := range d.overrides[day] {
+		rates[code] = rate
+	}
+
+	return rates
+}
+
+// seeded varies the reference rate by up to maxVariation, using a hash of the seed, day and currency so that the same
+// inputs always produce the same rate.
+func (d dataset) seeded(day, code string, rate float64) float64 {
+	if code == "USD" {
+		return 1
+	}
+
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(day + code))
+	fraction := float64((h.Sum64()^uint64(d.seed))%2001)/1000 - 1
+
+	return round(rate*(1+fraction*maxVariation), 10)
+}
+
+func round(v float64, places int) float64 {
+	pow := math.Pow(10, float64(places))
+
+	return math.Round(v*pow) / pow
+}
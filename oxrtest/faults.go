@@ -0,0 +1,290 @@
+package oxrtest
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Request is a request received by the Server.
+type Request struct {
+	Method string
+	// Path is relative to the base URL of the API, for example latest.json.
+	Path     string
+	Query    url.Values
+	Header   http.Header
+	Received time.Time
+}
+
+// Fault alters how the Server responds, allowing tests to observe how code behaves when OXR misbehaves. Faults apply to
+// every request unless limited using Times or OnPath.
+type Fault struct {
+	path        string
+	times       int
+	status      int
+	message     string
+	description string
+	latency     time.Duration
+	truncate    bool
+	corrupt     bool
+	drop        bool
+	staleBy     time.Duration
+}
+
+// FailWith responds with the given status and OXR error payload instead of serving the request. Failed requests are not
+// counted against the quota.
+func FailWith(status int, message, description string) Fault {
+	return Fault{status: status, message: message, description: description}
+}
+
+// Delay waits for the given duration, or until the request is cancelled, before responding.
+func Delay(latency time.Duration) Fault {
+	return Fault{latency: latency}
+}
+
+// TruncateBody responds with only the first half of a successful response body.
+func TruncateBody() Fault {
+	return Fault{truncate: true}
+}
+
+// CorruptBody responds with a successful response body which is no longer valid JSON.
+func CorruptBody() Fault {
+	return Fault{corrupt: true}
+}
+
+// DropConnection closes the connection part way through writing a successful response body.
+func DropConnection() Fault {
+	return Fault{drop: true}
+}
+
+// StaleTimestamps moves the timestamps of successful responses into the past by the given duration.
+func StaleTimestamps(by time.Duration) Fault {
+	return Fault{staleBy: by}
+}
+
+// Times limits the Fault to the next n matching requests.
+func (f Fault) Times(n int) Fault {
+	f.times = n
+	return f
+}
+
+// OnPath limits the Fault to requests whose path, relative to the base URL of the API, begins with the given prefix.
+func (f Fault) OnPath(prefix string) Fault {
+	f.path = prefix
+	return f
+}
+
+// WithFaults injects the given faults when the Server starts.
+func WithFaults(faults ...Fault) Option {
+	return func(s *Server) {
+		s.Inject(faults...)
+	}
+}
+
+// Inject adds faults which are applied, in the order given, to subsequent requests.
+func (s *Server) Inject(faults ...Fault) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i := range faults {
+		f := faults[i]
+		s.faults = append(s.faults, &f)
+	}
+}
+
+// ClearFaults removes every injected fault.
+func (s *Server) ClearFaults() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.faults = nil
+}
+
+// Received returns every request received by the Server, in the order they arrived.
+func (s *Server) Received() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]Request(nil), s.received...)
+}
+
+// serveHTTP records the request, applies any matching faults and otherwise serves the request as OXR would.
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	f := s.receive(r)
+
+	if f.latency > 0 {
+		timer := time.NewTimer(f.latency)
+		select {
+		case <-r.Context().Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+	}
+
+	if f.status != 0 {
+		writeError(w, f.status, f.message, f.description)
+		return
+	}
+
+	rec := httptest.NewRecorder()
+	s.handle(rec, r)
+
+	body := rec.Body.Bytes()
+	if rec.Code == http.StatusOK {
+		body = f.apply(body)
+	}
+
+	for k, v := range rec.Header() {
+		w.Header()[k] = v
+	}
+
+	if rec.Code == http.StatusOK && f.drop {
+		dropConnection(w, body)
+		return
+	}
+
+	w.Header().Set("Content-Length", strconv.Itoa(len(body)))
+	w.WriteHeader(rec.Code)
+	_, _ = w.Write(body)
+}
+
+// receive records the request and combines the faults which match it, consuming their remaining uses.
+func (s *Server) receive(r *http.Request) Fault {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	path := strings.TrimPrefix(r.URL.Path, "/api/")
+
+	s.received = append(s.received, Request{
+		Method:   r.Method,
+		Path:     path,
+		Query:    r.URL.Query(),
+		Header:   r.Header.Clone(),
+		Received: time.Now(),
+	})
+
+	var combined Fault
+	remaining := s.faults[:0]
+
+	for _, f := range s.faults {
+		if !strings.HasPrefix(path, f.path) {
+			remaining = append(remaining, f)
+			continue
+		}
+
+		combined.merge(*f)
+
+		if f.times > 0 {
+			f.times--
+			if f.times == 0 {
+				continue
+			}
+		}
+
+		remaining = append(remaining, f)
+	}
+
+	s.faults = remaining
+
+	return combined
+}
+
+func (f *Fault) merge(other Fault) {
+	if other.status != 0 && f.status == 0 {
+		f.status, f.message, f.description = other.status, other.message, other.description
+	}
+
+	f.latency += other.latency
+	f.staleBy += other.staleBy
+	f.truncate = f.truncate || other.truncate
+	f.corrupt = f.corrupt || other.corrupt
+	f.drop = f.drop || other.drop
+}
+
+// apply alters a successful response body according to the fault.
+func (f Fault) apply(body []byte) []byte {
+	if f.staleBy > 0 {
+		body = stale(body, f.staleBy)
+	}
+
+	if f.corrupt {
+		body = bytes.ReplaceAll(body, []byte(":"), []byte("="))
+	}
+
+	if f.truncate {
+		body = body[:len(body)/2]
+	}
+
+	return body
+}
+
+// stale moves the timestamp, and the meta timestamp of conversions, of a payload into the past.
+func stale(body []byte, by time.Duration) []byte {
+	var payload map[string]json.RawMessage
+	if json.Unmarshal(body, &payload) != nil {
+		return body
+	}
+
+	shift := func(raw json.RawMessage) json.RawMessage {
+		timestamp, err := strconv.ParseInt(string(raw), 10, 64)
+		if err != nil {
+			return raw
+		}
+
+		return json.RawMessage(strconv.FormatInt(timestamp-int64(by.Seconds()), 10))
+	}
+
+	if raw, ok := payload["timestamp"]; ok {
+		payload["timestamp"] = shift(raw)
+	}
+
+	if raw, ok := payload["meta"]; ok {
+		var meta map[string]json.RawMessage
+		if json.Unmarshal(raw, &meta) == nil {
+			if ts, ok := meta["timestamp"]; ok {
+				meta["timestamp"] = shift(ts)
+			}
+
+			if b, err := json.Marshal(meta); err == nil {
+				payload["meta"] = b
+			}
+		}
+	}
+
+	b, err := json.Marshal(payload)
+	if err != nil {
+		return body
+	}
+
+	return b
+}
+
+// dropConnection advertises the full length of the body but closes the connection after writing half of it.
+func dropConnection(w http.ResponseWriter, body []byte) {
+	hj, ok := w.(http.Hijacker)
+	if !ok {
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write(body[:len(body)/2])
+		return
+	}
+
+	conn, buf, err := hj.Hijack()
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+
+	_, _ = buf.WriteString("HTTP/1.1 200 OK\r\n")
+	w.Header().Set("Content-Length", strconv.Itoa(len(body)))
+	w.Header().Set("Connection", "close")
+	_ = w.Header().Write(buf)
+	_, _ = buf.WriteString("\r\n")
+	_, _ = buf.Write(body[:len(body)/2])
+	_ = buf.Flush()
+}
@@ -0,0 +1,149 @@
+package oxrtest_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jamieaitken/oxr"
+	"github.com/jamieaitken/oxr/oxrtest"
+)
+
+func TestServer_Faults(t *testing.T) {
+	tests := []struct {
+		name             string
+		givenFaults      []oxrtest.Fault
+		givenTimeout     time.Duration
+		expectedError    func(err error) bool
+		expectedRequests int
+	}{
+		{
+			name:        "given failure, expect api error",
+			givenFaults: []oxrtest.Fault{oxrtest.FailWith(http.StatusServiceUnavailable, "unavailable", "Try again later.")},
+			expectedError: func(err error) bool {
+				var apiErr *oxr.APIError
+				return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusServiceUnavailable
+			},
+		},
+		{
+			name:         "given latency beyond deadline, expect deadline exceeded",
+			givenFaults:  []oxrtest.Fault{oxrtest.Delay(time.Second)},
+			givenTimeout: 10 * time.Millisecond,
+			expectedError: func(err error) bool {
+				return errors.Is(err, context.DeadlineExceeded)
+			},
+		},
+		{
+			name:        "given truncated body, expect syntax error",
+			givenFaults: []oxrtest.Fault{oxrtest.TruncateBody()},
+			expectedError: func(err error) bool {
+				var syntaxErr *json.SyntaxError
+				return errors.As(err, &syntaxErr)
+			},
+			expectedRequests: 1,
+		},
+		{
+			name:        "given corrupt body, expect syntax error",
+			givenFaults: []oxrtest.Fault{oxrtest.CorruptBody()},
+			expectedError: func(err error) bool {
+				var syntaxErr *json.SyntaxError
+				return errors.As(err, &syntaxErr)
+			},
+			expectedRequests: 1,
+		},
+		{
+			name:        "given dropped connection, expect unexpected eof",
+			givenFaults: []oxrtest.Fault{oxrtest.DropConnection()},
+			expectedError: func(err error) bool {
+				return errors.Is(err, io.ErrUnexpectedEOF)
+			},
+			expectedRequests: 1,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s := oxrtest.NewServer(oxrtest.WithFaults(test.givenFaults...))
+			defer s.Close()
+
+			ctx := context.Background()
+			if test.givenTimeout > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, test.givenTimeout)
+				defer cancel()
+			}
+
+			_, err := s.Client().Latest(ctx)
+			if !test.expectedError(err) {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if !cmp.Equal(s.Requests(oxrtest.DefaultAppID), test.expectedRequests) {
+				t.Fatal(cmp.Diff(s.Requests(oxrtest.DefaultAppID), test.expectedRequests))
+			}
+		})
+	}
+}
+
+func TestServer_Faults_FailNext(t *testing.T) {
+	s := oxrtest.NewServer(oxrtest.WithFaults(
+		oxrtest.FailWith(http.StatusBadGateway, "bad_gateway", "Upstream unavailable.").Times(2).OnPath("latest.json"),
+	))
+	defer s.Close()
+
+	c := s.Client(oxr.WithDoer(oxr.NewRetryDoer(http.DefaultClient, oxr.RetryWithBaseDelay(time.Millisecond))))
+
+	if _, err := c.Currencies(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := c.Latest(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	var actual []string
+	for _, r := range s.Received() {
+		actual = append(actual, r.Path)
+	}
+
+	expected := []string{"currencies.json", "latest.json", "latest.json", "latest.json"}
+	if !cmp.Equal(actual, expected) {
+		t.Fatal(cmp.Diff(actual, expected))
+	}
+
+	if !cmp.Equal(s.Requests(oxrtest.DefaultAppID), 2) {
+		t.Fatal(cmp.Diff(s.Requests(oxrtest.DefaultAppID), 2))
+	}
+}
+
+func TestServer_Faults_StaleTimestamps(t *testing.T) {
+	s := oxrtest.NewServer(
+		oxrtest.WithClock(func() time.Time { return now }),
+		oxrtest.WithFaults(oxrtest.StaleTimestamps(2*time.Hour)),
+	)
+	defer s.Close()
+
+	latest, err := s.Client().Latest(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := now.Add(-2 * time.Hour).Unix()
+	if !cmp.Equal(latest.Timestamp, expected) {
+		t.Fatal(cmp.Diff(latest.Timestamp, expected))
+	}
+
+	conversion, err := s.Client().Convert(context.Background(), oxr.ConvertWithValue(1),
+		oxr.ConvertForBaseCurrency("USD"), oxr.ConvertForDestinationCurrency("GBP"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !cmp.Equal(conversion.Meta.Timestamp, expected) {
+		t.Fatal(cmp.Diff(conversion.Meta.Timestamp, expected))
+	}
+}
@@ -0,0 +1,385 @@
+package oxrtest
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jamieaitken/oxr"
+)
+
+const (
+	disclaimer = "Usage subject to terms: https://openexchangerates.org/terms"
+	license    = "https://openexchangerates.org/license"
+)
+
+var earliestDate = time.Date(1999, 1, 1, 0, 0, 0, 0, time.UTC)
+
+var periods = map[string]time.Duration{
+	"1m":  time.Minute,
+	"5m":  5 * time.Minute,
+	"15m": 15 * time.Minute,
+	"30m": 30 * time.Minute,
+	"1h":  time.Hour,
+	"12h": 12 * time.Hour,
+	"1d":  24 * time.Hour,
+	"1w":  7 * 24 * time.Hour,
+}
+
+// rateQuery holds the parameters shared by the endpoints which return rates.
+type rateQuery struct {
+	base            string
+	symbols         []string
+	showAlternative bool
+}
+
+// parseRateQuery validates the base and symbols parameters against the plan, writing an error response when invalid.
+func (s *Server) parseRateQuery(w http.ResponseWriter, r *http.Request, a *account) (rateQuery, bool) {
+	q := r.URL.Query()
+	rq := rateQuery{
+		base:            strings.ToUpper(q.Get("base")),
+		showAlternative: q.Get("show_alternative") == "true",
+	}
+
+	if rq.base == "" {
+		rq.base = "USD"
+	}
+
+	if rq.base != "USD" && !a.plan.Features.Base {
+		writeError(w, http.StatusForbidden, "not_allowed",
+			"Changing the API `base` currency is available for Developer, Enterprise and Unlimited plan clients.")
+		return rateQuery{}, false
+	}
+
+	if _, ok := s.data.currencies[rq.base]; !ok {
+		writeError(w, http.StatusBadRequest, "invalid_base", "Client requested rates for an unsupported base currency.")
+		return rateQuery{}, false
+	}
+
+	if symbols := q.Get("symbols"); symbols != "" {
+		if !a.plan.Features.Symbols {
+			writeError(w, http.StatusForbidden, "not_allowed", "Requesting specific `symbols` is not available for this plan.")
+			return rateQuery{}, false
+		}
+
+		for _, symbol := range strings.Split(symbols, ",") {
+			rq.symbols = append(rq.symbols, strings.ToUpper(strings.TrimSpace(symbol)))
+		}
+	}
+
+	return rq, true
+}
+
+// rates returns the rates of the given day rebased and filtered according to the query.
+func (s *Server) rates(date time.Time, rq rateQuery) map[string]float64 {
+	all := s.data.rates(date)
+	baseRate := all[rq.base]
+
+	include := func(code string) bool {
+		currency, ok := s.data.currencies[code]
+		if !ok || currency.Inactive {
+			return false
+		}
+
+		return !currency.Alternative || rq.showAlternative
+	}
+
+	rates := make(map[string]float64)
+	if len(rq.symbols) > 0 {
+		for _, code := range rq.symbols {
+			if rate, ok := all[code]; ok && include(code) {
+				rates[code] = round(rate/baseRate, 10)
+			}
+		}
+
+		return rates
+	}
+
+	for code, rate := range all {
+		if include(code) {
+			rates[code] = round(rate/baseRate, 10)
+		}
+	}
+
+	return rates
+}
+
+// latestTimestamp returns the time the latest rates were published, according to the update frequency of the plan.
+func (s *Server) latestTimestamp(a *account) time.Time {
+	interval, err := oxr.UsageDataPlan{UpdateFrequency: a.plan.UpdateFrequency}.UpdateInterval()
+	if err != nil {
+		interval = time.Hour
+	}
+
+	return s.now().UTC().Truncate(interval)
+}
+
+func (s *Server) latest(w http.ResponseWriter, r *http.Request, a *account) bool {
+	rq, ok := s.parseRateQuery(w, r, a)
+	if !ok {
+		return false
+	}
+
+	timestamp := s.latestTimestamp(a)
+
+	writeJSON(w, r, oxr.LatestRatesResponse{
+		Disclaimer: disclaimer,
+		License:    license,
+		Timestamp:  timestamp.Unix(),
+		Base:       rq.base,
+		Rates:      s.rates(timestamp, rq),
+	})
+
+	return true
+}
+
+func (s *Server) historical(w http.ResponseWriter, r *http.Request, a *account, day string) bool {
+	date, err := time.Parse(timeFormat, day)
+	if err != nil || date.Before(earliestDate) || date.After(s.now()) {
+		writeError(w, http.StatusBadRequest, "not_available", "Historical rates for the requested date are not available.")
+		return false
+	}
+
+	rq, ok := s.parseRateQuery(w, r, a)
+	if !ok {
+		return false
+	}
+
+	timestamp := date.Add(24*time.Hour - time.Second)
+	if timestamp.After(s.now()) {
+		timestamp = s.latestTimestamp(a)
+	}
+
+	writeJSON(w, r, oxr.HistoricalRatesResponse{
+		Disclaimer: disclaimer,
+		License:    license,
+		Timestamp:  timestamp.Unix(),
+		Base:       rq.base,
+		Rates:      s.rates(date, rq),
+	})
+
+	return true
+}
+
+func (s *Server) currencies(w http.ResponseWriter, r *http.Request) bool {
+	q := r.URL.Query()
+	showAlternative := q.Get("show_alternative") == "true"
+	showInactive := q.Get("show_inactive") == "true"
+
+	currencies := make(map[string]string)
+	for code, currency := range s.data.currencies {
+		if currency.Alternative && !showAlternative || currency.Inactive && !showInactive {
+			continue
+		}
+
+		currencies[code] = currency.Name
+	}
+
+	writeJSON(w, r, currencies)
+
+	return true
+}
+
+func (s *Server) timeSeries(w http.ResponseWriter, r *http.Request, a *account) bool {
+	if !a.plan.Features.TimeSeries {
+		writeError(w, http.StatusForbidden, "not_allowed", "The time-series API is not available for this plan.")
+		return false
+	}
+
+	q := r.URL.Query()
+	start, startErr := time.Parse(timeFormat, q.Get("start"))
+	end, endErr := time.Parse(timeFormat, q.Get("end"))
+	if startErr != nil || endErr != nil || end.Before(start) || start.Before(earliestDate) || end.After(s.now()) {
+		writeError(w, http.StatusBadRequest, "invalid_date_range", "Client requested an invalid date range.")
+		return false
+	}
+
+	rq, ok := s.parseRateQuery(w, r, a)
+	if !ok {
+		return false
+	}
+
+	rates := make(map[string]map[string]float64)
+	for date := start; !date.After(end); date = date.AddDate(0, 0, 1) {
+		rates[date.Format(timeFormat)] = s.rates(date, rq)
+	}
+
+	writeJSON(w, r, oxr.TimeSeriesResponse{
+		Disclaimer: disclaimer,
+		License:    license,
+		StartDate:  start.Format(timeFormat),
+		EndDate:    end.Format(timeFormat),
+		Base:       rq.base,
+		Rates:      rates,
+	})
+
+	return true
+}
+
+func (s *Server) convert(w http.ResponseWriter, r *http.Request, a *account, segments []string) bool {
+	if !a.plan.Features.Convert {
+		writeError(w, http.StatusForbidden, "not_allowed", "The convert API is not available for this plan.")
+		return false
+	}
+
+	if len(segments) != 3 {
+		writeError(w, http.StatusNotFound, "not_found", "Client requested a non-existent resource/route.")
+		return false
+	}
+
+	amount, err := strconv.ParseFloat(segments[0], 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_amount", "Client requested conversion of an invalid amount.")
+		return false
+	}
+
+	from, to := strings.ToUpper(segments[1]), strings.ToUpper(segments[2])
+	_, fromOK := s.data.currencies[from]
+	_, toOK := s.data.currencies[to]
+	if !fromOK || !toOK {
+		writeError(w, http.StatusBadRequest, "invalid_currency", "Client requested conversion of an unsupported currency.")
+		return false
+	}
+
+	timestamp := s.latestTimestamp(a)
+	all := s.data.rates(timestamp)
+	rate := round(all[to]/all[from], 10)
+
+	writeJSON(w, r, oxr.ConversionResponse{
+		Disclaimer: disclaimer,
+		License:    license,
+		Request: oxr.ConversionRequest{
+			Query:  fmt.Sprintf("/convert/%s/%s/%s", segments[0], from, to),
+			Amount: amount,
+			From:   from,
+			To:     to,
+		},
+		Meta: oxr.ConversionMeta{
+			Timestamp: timestamp.Unix(),
+			Rate:      rate,
+		},
+		Response: round(amount*rate, 10),
+	})
+
+	return true
+}
+
+func (s *Server) ohlc(w http.ResponseWriter, r *http.Request, a *account) bool {
+	q := r.URL.Query()
+
+	start, err := time.Parse(time.RFC3339, q.Get("start_date"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_start_date", "Client requested an invalid start date.")
+		return false
+	}
+
+	var end time.Time
+	if q.Get("period") == "1mo" {
+		end = start.AddDate(0, 1, 0)
+	} else {
+		period, ok := periods[q.Get("period")]
+		if !ok {
+			writeError(w, http.StatusBadRequest, "invalid_period", "Client requested an invalid period.")
+			return false
+		}
+
+		end = start.Add(period)
+	}
+
+	if end.After(s.now()) {
+		writeError(w, http.StatusBadRequest, "invalid_period", "Client requested a period which has not yet ended.")
+		return false
+	}
+
+	rq, ok := s.parseRateQuery(w, r, a)
+	if !ok {
+		return false
+	}
+
+	open := s.rates(start, rq)
+	closing := s.rates(end, rq)
+
+	codes := make([]string, 0, len(open))
+	for code := range open {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+
+	rates := make(map[string]oxr.OHLCRate, len(codes))
+	for _, code := range codes {
+		o, c := open[code], closing[code]
+		rates[code] = oxr.OHLCRate{
+			Open:    o,
+			High:    maxFloat(o, c),
+			Low:     minFloat(o, c),
+			Close:   c,
+			Average: round((o+c)/2, 10),
+		}
+	}
+
+	writeJSON(w, r, oxr.OHLCResponse{
+		Disclaimer: disclaimer,
+		License:    license,
+		StartTime:  start.UTC(),
+		EndTime:    end.UTC(),
+		Base:       rq.base,
+		Rates:      rates,
+	})
+
+	return true
+}
+
+func (s *Server) usage(w http.ResponseWriter, r *http.Request, appID string, a *account) {
+	now := s.now().UTC()
+	periodStart := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	daysElapsed := int(now.Sub(periodStart).Hours()/24) + 1
+	daysRemaining := int(s.periodEnd().Sub(now).Hours() / 24)
+
+	quota := "Unlimited"
+	remaining := -1
+	if a.plan.Quota > 0 {
+		quota = fmt.Sprintf("%d requests / month", a.plan.Quota)
+		remaining = a.plan.Quota - a.requests
+	}
+
+	writeJSON(w, r, oxr.UsageResponse{
+		Status: http.StatusOK,
+		Data: oxr.UsageData{
+			AppID:  appID,
+			Status: "active",
+			Plan: oxr.UsageDataPlan{
+				Name:            a.plan.Name,
+				Quota:           quota,
+				UpdateFrequency: a.plan.UpdateFrequency,
+				Features:        a.plan.Features,
+			},
+			Usage: oxr.DataUsage{
+				Requests:          a.requests,
+				RequestsQuota:     a.plan.Quota,
+				RequestsRemaining: remaining,
+				DaysElapsed:       daysElapsed,
+				DaysRemaining:     daysRemaining,
+				DailyAverage:      a.requests / daysElapsed,
+			},
+		},
+	})
+}
+
+func maxFloat(a, b float64) float64 {
+	if a > b {
+		return a
+	}
+
+	return b
+}
+
+func minFloat(a, b float64) float64 {
+	if a < b {
+		return a
+	}
+
+	return b
+}
@@ -0,0 +1,301 @@
+// Package oxrtest provides a local fake of the Open Exchange Rates API for use in tests.
+package oxrtest
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jamieaitken/oxr"
+)
+
+// DefaultAppID is the App ID registered on a Server unless WithAccount is used.
+const DefaultAppID = "oxrtest"
+
+// Plan describes the entitlements of an App ID.
+type Plan struct {
+	Name            string
+	UpdateFrequency string
+	// Quota is the number of requests allowed per month. Zero is unlimited.
+	Quota    int
+	Features oxr.UsageDataPlanFeatures
+}
+
+// Plans offered by OXR.
+var (
+	FreePlan = Plan{
+		Name:            "Free",
+		UpdateFrequency: "60-minute",
+		Quota:           1000,
+		Features:        oxr.UsageDataPlanFeatures{Symbols: true},
+	}
+	DeveloperPlan = Plan{
+		Name:            "Developer",
+		UpdateFrequency: "60-minute",
+		Quota:           10000,
+		Features:        oxr.UsageDataPlanFeatures{Base: true, Symbols: true},
+	}
+	EnterprisePlan = Plan{
+		Name:            "Enterprise",
+		UpdateFrequency: "30-minute",
+		Quota:           100000,
+		Features:        oxr.UsageDataPlanFeatures{Base: true, Symbols: true, Experimental: true, TimeSeries: true},
+	}
+	UnlimitedPlan = Plan{
+		Name:            "Unlimited",
+		UpdateFrequency: "5-minute",
+		Features: oxr.UsageDataPlanFeatures{
+			Base: true, Symbols: true, Experimental: true, TimeSeries: true, Convert: true,
+		},
+	}
+)
+
+// Server is a httptest.Server implementing every OXR endpoint from a seeded dataset. It enforces App IDs, plan features
+// and quotas in the same way as OXR.
+type Server struct {
+	*httptest.Server
+
+	now func() time.Time
+
+	mu       sync.Mutex
+	data     dataset
+	accounts map[string]*account
+	faults   []*Fault
+	received []Request
+}
+
+type account struct {
+	plan     Plan
+	requests int
+}
+
+// Option allows a Server to be modified.
+type Option func(*Server)
+
+// NewServer starts a Server. It should be closed once the test has finished.
+func NewServer(opts ...Option) *Server {
+	s := &Server{
+		now: time.Now,
+		data: dataset{
+			currencies: DefaultCurrencies(),
+			overrides:  make(map[string]map[string]float64),
+		},
+		accounts: make(map[string]*account),
+	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	if len(s.accounts) == 0 {
+		s.accounts[DefaultAppID] = &account{plan: UnlimitedPlan}
+	}
+
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+
+	return s
+}
+
+// WithAccount registers an App ID on the given Plan. When no accounts are registered, DefaultAppID is registered on the
+// UnlimitedPlan.
+func WithAccount(appID string, plan Plan) Option {
+	return func(s *Server) {
+		s.accounts[appID] = &account{plan: plan}
+	}
+}
+
+// WithSeed sets the seed from which daily rates are derived.
+func WithSeed(seed int64) Option {
+	return func(s *Server) {
+		s.data.seed = seed
+	}
+}
+
+// WithCurrencies replaces the currencies served.
+func WithCurrencies(currencies map[string]Currency) Option {
+	return func(s *Server) {
+		s.data.currencies = currencies
+	}
+}
+
+// WithRates sets exact rates, per US Dollar, for the given day instead of seeded ones.
+func WithRates(date time.Time, rates map[string]float64) Option {
+	return func(s *Server) {
+		s.SetRates(date, rates)
+	}
+}
+
+// WithClock sets the source of the current time, which decides the latest rates and usage period.
+func WithClock(now func() time.Time) Option {
+	return func(s *Server) {
+		s.now = now
+	}
+}
+
+// BaseURL returns the base URL of the fake API, suitable for oxr.WithBaseURL.
+func (s *Server) BaseURL() string {
+	return s.Server.URL + "/api/"
+}
+
+// Client returns an oxr.Client configured to send requests to the Server using DefaultAppID. Any options given are
+// applied afterwards, so may override the App ID.
+func (s *Server) Client(opts ...oxr.ClientOption) oxr.Client {
+	return oxr.New(append([]oxr.ClientOption{
+		oxr.WithAppID(DefaultAppID),
+		oxr.WithDoer(s.Server.Client()),
+		oxr.WithBaseURL(s.BaseURL()),
+	}, opts...)...)
+}
+
+// SetRates sets exact rates, per US Dollar, for the given day instead of seeded ones.
+func (s *Server) SetRates(date time.Time, rates map[string]float64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	day := date.UTC().Format(timeFormat)
+	if s.data.overrides[day] == nil {
+		s.data.overrides[day] = make(map[string]float64)
+	}
+
+	for code, rate := range rates {
+		s.data.overrides[day][code] = rate
+	}
+}
+
+// Requests returns the number of requests counted against the quota of the App ID.
+func (s *Server) Requests(appID string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if a, ok := s.accounts[appID]; ok {
+		return a.requests
+	}
+
+	return 0
+}
+
+// ResetQuota resets the number of requests counted against the quota of every App ID.
+func (s *Server) ResetQuota() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, a := range s.accounts {
+		a.requests = 0
+	}
+}
+
+// handle serves the request as OXR would.
+func (s *Server) handle(w http.ResponseWriter, r *http.Request) {
+	path := strings.TrimPrefix(r.URL.Path, "/api/")
+	if path == r.URL.Path || r.Method != http.MethodGet {
+		writeError(w, http.StatusNotFound, "not_found", "Client requested a non-existent resource/route.")
+		return
+	}
+
+	appID := r.URL.Query().Get("app_id")
+	if auth := r.Header.Get("Authorization"); appID == "" && strings.HasPrefix(auth, "Token ") {
+		appID = strings.TrimPrefix(auth, "Token ")
+	}
+
+	if appID == "" {
+		writeError(w, http.StatusUnauthorized, "missing_app_id", "No App ID provided.")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	a, ok := s.accounts[appID]
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "invalid_app_id", "Invalid App ID provided.")
+		return
+	}
+
+	if path == "usage.json" {
+		s.usage(w, r, appID, a)
+		return
+	}
+
+	if a.plan.Quota > 0 && a.requests >= a.plan.Quota {
+		writeError(w, http.StatusTooManyRequests, "access_restricted",
+			fmt.Sprintf("Access restricted until %s (reason: too_many_requests).", s.periodEnd().Format(timeFormat)))
+		return
+	}
+
+	var handled bool
+	switch {
+	case path == "latest.json":
+		handled = s.latest(w, r, a)
+	case strings.HasPrefix(path, "historical/"):
+		handled = s.historical(w, r, a, strings.TrimSuffix(strings.TrimPrefix(path, "historical/"), ".json"))
+	case path == "currencies.json":
+		handled = s.currencies(w, r)
+	case path == "time-series.json":
+		handled = s.timeSeries(w, r, a)
+	case strings.HasPrefix(path, "convert/"):
+		handled = s.convert(w, r, a, strings.Split(strings.TrimPrefix(path, "convert/"), "/"))
+	case path == "ohlc.json":
+		handled = s.ohlc(w, r, a)
+	default:
+		writeError(w, http.StatusNotFound, "not_found", "Client requested a non-existent resource/route.")
+	}
+
+	if handled {
+		a.requests++
+	}
+}
+
+// periodEnd returns the start of the next billing period, which is assumed to be monthly.
+func (s *Server) periodEnd() time.Time {
+	now := s.now().UTC()
+
+	return time.Date(now.Year(), now.Month()+1, 1, 0, 0, 0, 0, time.UTC)
+}
+
+// writeJSON writes v with an ETag of its digest, answering with 304 Not Modified should it match If-None-Match.
+func writeJSON(w http.ResponseWriter, r *http.Request, v interface{}) {
+	var buf bytes.Buffer
+
+	enc := json.NewEncoder(&buf)
+	if r.URL.Query().Get("prettyprint") == "true" {
+		enc.SetIndent("", "  ")
+	}
+
+	_ = enc.Encode(v)
+
+	sum := sha256.Sum256(buf.Bytes())
+	etag := `"` + hex.EncodeToString(sum[:8]) + `"`
+
+	w.Header().Set("ETag", etag)
+	if r.Header.Get("If-None-Match") == etag {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	_, _ = w.Write(buf.Bytes())
+}
+
+func writeError(w http.ResponseWriter, status int, message, description string) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+
+	_ = json.NewEncoder(w).Encode(struct {
+		Error       bool   `json:"error"`
+		Status      int    `json:"status"`
+		Message     string `json:"message"`
+		Description string `json:"description"`
+	}{
+		Error:       true,
+		Status:      status,
+		Message:     message,
+		Description: description,
+	})
+}
@@ -0,0 +1,368 @@
+package oxrtest_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/jamieaitken/oxr"
+	"github.com/jamieaitken/oxr/oxrtest"
+)
+
+var now = time.Date(2022, 3, 16, 12, 10, 0, 0, time.UTC)
+
+func TestServer_Endpoints(t *testing.T) {
+	s := oxrtest.NewServer(
+		oxrtest.WithClock(func() time.Time { return now }),
+		oxrtest.WithRates(now, map[string]float64{"GBP": 0.8, "EUR": 0.9}),
+		oxrtest.WithRates(time.Date(2022, 3, 10, 0, 0, 0, 0, time.UTC), map[string]float64{"GBP": 0.75, "EUR": 0.95}),
+	)
+	defer s.Close()
+
+	c := s.Client()
+	ctx := context.Background()
+
+	latest, err := c.Latest(ctx, oxr.LatestForBaseCurrency("GBP"), oxr.LatestForDestinationCurrencies([]string{"EUR", "USD"}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedLatest := oxr.LatestRatesResponse{
+		Disclaimer: "Usage subject to terms: https://openexchangerates.org/terms",
+		License:    "https://openexchangerates.org/license",
+		Timestamp:  time.Date(2022, 3, 16, 12, 10, 0, 0, time.UTC).Unix(),
+		Base:       "GBP",
+		Rates:      map[string]float64{"EUR": 1.125, "USD": 1.25},
+		ExactRates: map[string]oxr.Decimal{"EUR": oxr.MustParseDecimal("1.125"), "USD": oxr.MustParseDecimal("1.25")},
+	}
+	if !cmp.Equal(latest, expectedLatest) {
+		t.Fatal(cmp.Diff(latest, expectedLatest))
+	}
+
+	historical, err := c.Historical(ctx,
+		oxr.HistoricalForDate(time.Date(2022, 3, 10, 0, 0, 0, 0, time.UTC)),
+		oxr.HistoricalForDestinationCurrencies([]string{"GBP", "EUR"}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedHistoricalRates := map[string]float64{"GBP": 0.75, "EUR": 0.95}
+	if !cmp.Equal(historical.Rates, expectedHistoricalRates) {
+		t.Fatal(cmp.Diff(historical.Rates, expectedHistoricalRates))
+	}
+
+	currencies, err := c.Currencies(ctx, oxr.CurrenciesWithAlternatives(true))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, ok := currencies.Currencies["BTC"]; !ok {
+		t.Fatal("expected alternative currency BTC to be listed")
+	}
+
+	if _, ok := currencies.Currencies["VEF"]; ok {
+		t.Fatal("expected inactive currency VEF not to be listed")
+	}
+
+	timeSeries, err := c.TimeSeries(ctx,
+		oxr.TimeSeriesForStartDate(time.Date(2022, 3, 10, 0, 0, 0, 0, time.UTC)),
+		oxr.TimeSeriesForEndDate(time.Date(2022, 3, 12, 0, 0, 0, 0, time.UTC)),
+		oxr.TimeSeriesForDestinationCurrencies([]string{"GBP"}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !cmp.Equal(len(timeSeries.Rates), 3) {
+		t.Fatal(cmp.Diff(len(timeSeries.Rates), 3))
+	}
+
+	if !cmp.Equal(timeSeries.Rates["2022-03-10"], map[string]float64{"GBP": 0.75}) {
+		t.Fatal(cmp.Diff(timeSeries.Rates["2022-03-10"], map[string]float64{"GBP": 0.75}))
+	}
+
+	conversion, err := c.Convert(ctx,
+		oxr.ConvertWithValue(100),
+		oxr.ConvertForBaseCurrency("GBP"),
+		oxr.ConvertForDestinationCurrency("USD"),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !cmp.Equal(conversion.Response, 125.0) {
+		t.Fatal(cmp.Diff(conversion.Response, 125.0))
+	}
+
+	ohlc, err := c.OpenHighLowClose(ctx,
+		oxr.OHLCForStartTime(time.Date(2022, 3, 15, 13, 0, 0, 0, time.UTC)),
+		oxr.OHLCForPeriod(oxr.TwelveHour),
+		oxr.OHLCForDestinationCurrencies([]string{"GBP"}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !cmp.Equal(ohlc.EndTime, time.Date(2022, 3, 16, 1, 0, 0, 0, time.UTC)) {
+		t.Fatal(cmp.Diff(ohlc.EndTime, time.Date(2022, 3, 16, 1, 0, 0, 0, time.UTC)))
+	}
+
+	if !cmp.Equal(ohlc.Rates["GBP"].Close, 0.8) {
+		t.Fatal(cmp.Diff(ohlc.Rates["GBP"].Close, 0.8))
+	}
+
+	usage, err := c.Usage(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !cmp.Equal(usage.Data.Usage.Requests, 6) {
+		t.Fatal(cmp.Diff(usage.Data.Usage.Requests, 6))
+	}
+
+	if !cmp.Equal(usage.Data.Plan.Features, oxrtest.UnlimitedPlan.Features) {
+		t.Fatal(cmp.Diff(usage.Data.Plan.Features, oxrtest.UnlimitedPlan.Features))
+	}
+}
+
+func TestServer_SeededRates(t *testing.T) {
+	date := oxr.HistoricalForDate(time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC))
+
+	first := oxrtest.NewServer(oxrtest.WithSeed(42))
+	defer first.Close()
+
+	second := oxrtest.NewServer(oxrtest.WithSeed(42))
+	defer second.Close()
+
+	a, err := first.Client().Historical(context.Background(), date)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := second.Client().Historical(context.Background(), date)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !cmp.Equal(a, b) {
+		t.Fatal(cmp.Diff(a, b))
+	}
+
+	gbp := a.Rates["GBP"]
+	reference := oxrtest.DefaultCurrencies()["GBP"].Rate
+	if gbp < reference*0.98 || gbp > reference*1.02 {
+		t.Fatalf("expected GBP within 2%% of %v, got %v", reference, gbp)
+	}
+}
+
+func TestServer_Enforcement(t *testing.T) {
+	quotaPlan := oxrtest.FreePlan
+	quotaPlan.Quota = 1
+
+	s := oxrtest.NewServer(
+		oxrtest.WithClock(func() time.Time { return now }),
+		oxrtest.WithAccount(oxrtest.DefaultAppID, oxrtest.UnlimitedPlan),
+		oxrtest.WithAccount("free", oxrtest.FreePlan),
+		oxrtest.WithAccount("quota", quotaPlan),
+	)
+	defer s.Close()
+
+	tests := []struct {
+		name          string
+		givenCall     func(c oxr.Client) error
+		givenAppID    string
+		expectedError error
+	}{
+		{
+			name:       "given missing app id, expect ErrMissingAppID",
+			givenAppID: "",
+			givenCall: func(c oxr.Client) error {
+				_, err := c.Latest(context.Background())
+				return err
+			},
+			expectedError: oxr.ErrMissingAppID,
+		},
+		{
+			name:       "given unknown app id, expect ErrInvalidAppID",
+			givenAppID: "unknown",
+			givenCall: func(c oxr.Client) error {
+				_, err := c.Latest(context.Background())
+				return err
+			},
+			expectedError: oxr.ErrInvalidAppID,
+		},
+		{
+			name:       "given base change without feature, expect ErrNotAllowed",
+			givenAppID: "free",
+			givenCall: func(c oxr.Client) error {
+				_, err := c.Latest(context.Background(), oxr.LatestForBaseCurrency("GBP"))
+				return err
+			},
+			expectedError: oxr.ErrNotAllowed,
+		},
+		{
+			name:       "given time series without feature, expect ErrNotAllowed",
+			givenAppID: "free",
+			givenCall: func(c oxr.Client) error {
+				_, err := c.TimeSeries(context.Background(),
+					oxr.TimeSeriesForStartDate(time.Date(2022, 3, 1, 0, 0, 0, 0, time.UTC)),
+					oxr.TimeSeriesForEndDate(time.Date(2022, 3, 2, 0, 0, 0, 0, time.UTC)),
+				)
+				return err
+			},
+			expectedError: oxr.ErrNotAllowed,
+		},
+		{
+			name:       "given unknown base, expect ErrInvalidBase",
+			givenAppID: oxrtest.DefaultAppID,
+			givenCall: func(c oxr.Client) error {
+				_, err := c.Latest(context.Background(), oxr.LatestForBaseCurrency("ZAR"))
+				return err
+			},
+			expectedError: oxr.ErrInvalidBase,
+		},
+		{
+			name:       "given exhausted quota, expect ErrQuotaExceeded",
+			givenAppID: "quota",
+			givenCall: func(c oxr.Client) error {
+				if _, err := c.Latest(context.Background()); err != nil {
+					return err
+				}
+
+				_, err := c.Latest(context.Background())
+				return err
+			},
+			expectedError: oxr.ErrQuotaExceeded,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.givenCall(s.Client(oxr.WithAppID(test.givenAppID)))
+			if !errors.Is(err, test.expectedError) {
+				t.Fatalf("expected %v, got %v", test.expectedError, err)
+			}
+		})
+	}
+
+	if !cmp.Equal(s.Requests("quota"), 1) {
+		t.Fatal(cmp.Diff(s.Requests("quota"), 1))
+	}
+}
+
+func TestServer_AuthorizationHeader(t *testing.T) {
+	s := oxrtest.NewServer()
+	defer s.Close()
+
+	c := s.Client(oxr.WithAuthMode(oxr.AuthHeader))
+
+	if _, err := c.Latest(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestServer_AppIDFailover(t *testing.T) {
+	s := oxrtest.NewServer(
+		oxrtest.WithClock(func() time.Time { return now }),
+		oxrtest.WithAccount("limited", oxrtest.Plan{Name: "Limited", UpdateFrequency: "3600s", Quota: 1}),
+		oxrtest.WithAccount(oxrtest.DefaultAppID, oxrtest.UnlimitedPlan),
+	)
+	defer s.Close()
+
+	c := s.Client(oxr.WithAppIDs("limited", oxrtest.DefaultAppID))
+
+	for i := 0; i < 3; i++ {
+		if _, err := c.Latest(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if !cmp.Equal(s.Requests("limited"), 1) {
+		t.Fatal(cmp.Diff(s.Requests("limited"), 1))
+	}
+
+	if !cmp.Equal(s.Requests(oxrtest.DefaultAppID), 2) {
+		t.Fatal(cmp.Diff(s.Requests(oxrtest.DefaultAppID), 2))
+	}
+}
+
+func TestServer_LocalRebase(t *testing.T) {
+	s := oxrtest.NewServer(
+		oxrtest.WithClock(func() time.Time { return now }),
+		oxrtest.WithRates(now, map[string]float64{"GBP": 0.8, "EUR": 0.9}),
+		oxrtest.WithAccount(oxrtest.DefaultAppID, oxrtest.FreePlan),
+	)
+	defer s.Close()
+
+	c := s.Client(oxr.WithLocalRebase())
+
+	actual, err := c.Latest(context.Background(), oxr.LatestForBaseCurrency("GBP"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !actual.Derived || actual.Base != "GBP" {
+		t.Fatalf("expected rates derived for GBP, got %+v", actual)
+	}
+
+	expected := map[string]float64{"EUR": 1.125, "USD": 1.25}
+	for currency, rate := range expected {
+		if !cmp.Equal(actual.Rates[currency], rate, cmpopts.EquateApprox(0, 1e-9)) {
+			t.Fatal(cmp.Diff(actual.Rates[currency], rate))
+		}
+	}
+}
+
+func TestServer_ConditionalRequests(t *testing.T) {
+	s := oxrtest.NewServer(
+		oxrtest.WithClock(func() time.Time { return now }),
+		oxrtest.WithRates(now, map[string]float64{"GBP": 0.8}),
+	)
+	defer s.Close()
+
+	var statuses []int
+	c := s.Client(oxr.WithMiddleware(func(next oxr.Doer) oxr.Doer {
+		return oxr.DoerFunc(func(r *http.Request) (*http.Response, error) {
+			res, err := next.Do(r)
+			if err == nil {
+				statuses = append(statuses, res.StatusCode)
+			}
+
+			return res, err
+		})
+	}))
+
+	first, err := c.Latest(context.Background(), oxr.LatestForDestinationCurrencies([]string{"GBP"}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	second, err := c.Latest(context.Background(), oxr.LatestForDestinationCurrencies([]string{"GBP"}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !cmp.Equal(second, first) {
+		t.Fatal(cmp.Diff(second, first))
+	}
+
+	s.SetRates(now, map[string]float64{"GBP": 0.9})
+
+	third, err := c.Latest(context.Background(), oxr.LatestForDestinationCurrencies([]string{"GBP"}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !cmp.Equal(third.Rates["GBP"], 0.9) {
+		t.Fatal(cmp.Diff(third.Rates["GBP"], 0.9))
+	}
+
+	expected := []int{http.StatusOK, http.StatusNotModified, http.StatusOK}
+	if !cmp.Equal(statuses, expected) {
+		t.Fatal(cmp.Diff(statuses, expected))
+	}
+}
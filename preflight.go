@@ -0,0 +1,139 @@
+package oxr
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+const defaultPreflightRefresh = time.Hour
+
+// Feature is a capability of an OXR plan, as reported by the Usage endpoint.
+type Feature string
+
+// Available features.
+const (
+	FeatureBase       Feature = "base"
+	FeatureSymbols    Feature = "symbols"
+	FeatureTimeSeries Feature = "time-series"
+	FeatureConvert    Feature = "convert"
+)
+
+// String implements a fmt.Stringer for Feature.
+func (f Feature) String() string {
+	return string(f)
+}
+
+// FeatureError is returned, without a request being sent, when the plan does not support a Feature required by the
+// call. It matches ErrNotAllowed using errors.Is, as OXR would have responded.
+type FeatureError struct {
+	Endpoint Endpoint
+	Feature  Feature
+}
+
+// Error implements the error interface for FeatureError.
+func (e *FeatureError) Error() string {
+	return fmt.Sprintf("%s: plan does not support the %s feature: %v", e.Endpoint, e.Feature, ErrNotAllowed)
+}
+
+// Is allows a FeatureError to be compared against ErrNotAllowed using errors.Is.
+func (e *FeatureError) Is(target error) bool {
+	return target == ErrNotAllowed
+}
+
+// planFeatures holds the plan features loaded from the Usage endpoint.
+type planFeatures struct {
+	refresh time.Duration
+
+	mu       sync.Mutex
+	features UsageDataPlanFeatures
+	loaded   bool
+	loadedAt time.Time
+	retryAt  time.Time
+}
+
+// load returns the plan features, loading them using the Client when stale. The boolean is false should they be
+// unavailable. The Usage request is made without holding the lock, so concurrent loads share it rather than queue, and a
+// failed load is retried after a short backoff.
+func (p *planFeatures) load(ctx context.Context, c Client) (UsageDataPlanFeatures, bool) {
+	p.mu.Lock()
+	now := time.Now()
+	stale := now.Sub(p.loadedAt) >= p.refresh && !now.Before(p.retryAt)
+	features, loaded := p.features, p.loaded
+	p.mu.Unlock()
+
+	if !stale {
+		return features, loaded
+	}
+
+	usage, err := c.Usage(ctx)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if err != nil {
+		p.retryAt = now.Add(minDuration(limiterRefreshBackoff, p.refresh))
+		return p.features, p.loaded
+	}
+
+	p.features, p.loaded, p.loadedAt = usage.Data.Plan.Features, true, now
+
+	return p.features, p.loaded
+}
+
+// supports reports whether the plan supports the feature. Should the features be unavailable, the feature is assumed
+// to be supported and OXR left to decide.
+func (p *planFeatures) supports(ctx context.Context, c Client, feature Feature) bool {
+	features, ok := p.load(ctx, c)
+	if !ok {
+		return true
+	}
+
+	switch feature {
+	case FeatureBase:
+		return features.Base
+	case FeatureSymbols:
+		return features.Symbols
+	case FeatureTimeSeries:
+		return features.TimeSeries
+	case FeatureConvert:
+		return features.Convert
+	}
+
+	return true
+}
+
+// preflight fails with a FeatureError when the plan does not support a feature required by the call.
+func (c Client) preflight(ctx context.Context, cl call) error {
+	for _, feature := range cl.requiredFeatures() {
+		if !c.plan.supports(ctx, c, feature) {
+			return &FeatureError{Endpoint: cl.endpoint, Feature: feature}
+		}
+	}
+
+	return nil
+}
+
+// requiredFeatures returns the plan features needed for OXR to accept the call.
+func (cl call) requiredFeatures() []Feature {
+	var features []Feature
+
+	switch cl.endpoint {
+	case EndpointTimeSeries:
+		features = append(features, FeatureTimeSeries)
+	case EndpointConvert:
+		features = append(features, FeatureConvert)
+	}
+
+	if base := cl.query.Get("base"); base != "" && !strings.EqualFold(base, "USD") {
+		features = append(features, FeatureBase)
+	}
+
+	if cl.query.Get("symbols") != "" {
+		features = append(features, FeatureSymbols)
+	}
+
+	return features
+}
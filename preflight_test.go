@@ -0,0 +1,152 @@
+package oxr_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jamieaitken/oxr"
+)
+
+func TestWithPreflight(t *testing.T) {
+	tests := []struct {
+		name             string
+		givenUsage       mockResult
+		givenCall        func(c oxr.Client) error
+		expectedErr      *oxr.FeatureError
+		expectedRequests int
+	}{
+		{
+			name:       "given plan without base, expect latest for other base to fail fast",
+			givenUsage: mockResult{StatusCode: http.StatusOK, Body: freePlanUsage()},
+			givenCall: func(c oxr.Client) error {
+				_, err := c.Latest(context.Background(), oxr.LatestForBaseCurrency("GBP"))
+				return err
+			},
+			expectedErr: &oxr.FeatureError{Endpoint: oxr.EndpointLatest, Feature: oxr.FeatureBase},
+		},
+		{
+			name:       "given plan without base, expect latest for USD base to be sent",
+			givenUsage: mockResult{StatusCode: http.StatusOK, Body: freePlanUsage()},
+			givenCall: func(c oxr.Client) error {
+				_, err := c.Latest(context.Background(), oxr.LatestForBaseCurrency("usd"))
+				return err
+			},
+			expectedRequests: 1,
+		},
+		{
+			name:       "given plan without symbols, expect historical with symbols to fail fast",
+			givenUsage: mockResult{StatusCode: http.StatusOK, Body: freePlanUsage()},
+			givenCall: func(c oxr.Client) error {
+				_, err := c.Historical(context.Background(),
+					oxr.HistoricalForDate(time.Date(2022, 3, 1, 0, 0, 0, 0, time.UTC)),
+					oxr.HistoricalForDestinationCurrencies([]string{"GBP"}),
+				)
+				return err
+			},
+			expectedErr: &oxr.FeatureError{Endpoint: oxr.EndpointHistorical, Feature: oxr.FeatureSymbols},
+		},
+		{
+			name:       "given plan without time series, expect time series to fail fast",
+			givenUsage: mockResult{StatusCode: http.StatusOK, Body: freePlanUsage()},
+			givenCall: func(c oxr.Client) error {
+				_, err := c.TimeSeries(context.Background())
+				return err
+			},
+			expectedErr: &oxr.FeatureError{Endpoint: oxr.EndpointTimeSeries, Feature: oxr.FeatureTimeSeries},
+		},
+		{
+			name:       "given plan without convert, expect convert to fail fast",
+			givenUsage: mockResult{StatusCode: http.StatusOK, Body: successfulUsage()},
+			givenCall: func(c oxr.Client) error {
+				_, err := c.Convert(context.Background(), oxr.ConvertForBaseCurrency("GBP"), oxr.ConvertForDestinationCurrency("USD"))
+				return err
+			},
+			expectedErr: &oxr.FeatureError{Endpoint: oxr.EndpointConvert, Feature: oxr.FeatureConvert},
+		},
+		{
+			name:       "given usage unavailable, expect call to be sent",
+			givenUsage: mockResult{StatusCode: http.StatusInternalServerError},
+			givenCall: func(c oxr.Client) error {
+				_, err := c.Latest(context.Background(), oxr.LatestForBaseCurrency("GBP"))
+				return err
+			},
+			expectedRequests: 1,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var requests int
+
+			doer := oxr.DoerFunc(func(r *http.Request) (*http.Response, error) {
+				result := test.givenUsage
+				if !strings.HasSuffix(r.URL.Path, "usage.json") {
+					requests++
+					result = mockResult{StatusCode: http.StatusOK, Body: successfulLatest()}
+				}
+
+				return (&sequenceDoer{GivenResults: []mockResult{result}}).Do(r)
+			})
+
+			c := oxr.New(oxr.WithAppID("test"), oxr.WithDoer(doer), oxr.WithPreflight())
+
+			err := test.givenCall(c)
+
+			if test.expectedErr == nil {
+				if err != nil {
+					t.Fatal(err)
+				}
+			} else {
+				var actual *oxr.FeatureError
+				if !errors.As(err, &actual) {
+					t.Fatalf("expected *oxr.FeatureError, got %v", err)
+				}
+
+				if !cmp.Equal(actual, test.expectedErr) {
+					t.Fatal(cmp.Diff(actual, test.expectedErr))
+				}
+
+				if !errors.Is(err, oxr.ErrNotAllowed) {
+					t.Fatalf("expected %v to match %v", err, oxr.ErrNotAllowed)
+				}
+			}
+
+			if !cmp.Equal(requests, test.expectedRequests) {
+				t.Fatal(cmp.Diff(requests, test.expectedRequests))
+			}
+		})
+	}
+}
+
+func freePlanUsage() string {
+	return strings.NewReplacer(
+		`"base": true`, `"base": false`,
+		`"symbols": true`, `"symbols": false`,
+		`"time-series": true`, `"time-series": false`,
+	).Replace(successfulUsage())
+}
+
+func TestWithPreflight_UsageFailure(t *testing.T) {
+	doer := &sequenceDoer{GivenResults: []mockResult{
+		{StatusCode: http.StatusServiceUnavailable},
+		{StatusCode: http.StatusOK, Body: successfulLatest()},
+		{StatusCode: http.StatusOK, Body: successfulLatest()},
+	}}
+
+	c := oxr.New(oxr.WithAppID("test"), oxr.WithDoer(doer), oxr.WithPreflight())
+
+	for i := 0; i < 2; i++ {
+		if _, err := c.Latest(context.Background(), oxr.LatestForBaseCurrency("GBP")); err != nil {
+			t.Fatalf("expected call to be sent while features are unavailable, got %v", err)
+		}
+	}
+
+	// The failed load is not retried until its backoff has elapsed.
+	if !cmp.Equal(doer.Calls(), 3) {
+		t.Fatal(cmp.Diff(doer.Calls(), 3))
+	}
+}
@@ -0,0 +1,167 @@
+package oxr
+
+import (
+	"encoding/json"
+)
+
+// UnmarshalJSON decodes the response, decoding the rates exactly into ExactRates as well as into Rates.
+func (r *LatestRatesResponse) UnmarshalJSON(b []byte) error {
+	type response LatestRatesResponse
+
+	aux := struct {
+		*response
+		Rates map[string]Decimal `json:"rates"`
+	}{response: (*response)(r)}
+
+	err := json.Unmarshal(b, &aux)
+	if err != nil {
+		return err
+	}
+
+	r.Rates, r.ExactRates = floatRates(aux.Rates), aux.Rates
+
+	return nil
+}
+
+// UnmarshalJSON decodes the response, decoding the rates exactly into ExactRates as well as into Rates.
+func (r *HistoricalRatesResponse) UnmarshalJSON(b []byte) error {
+	type response HistoricalRatesResponse
+
+	aux := struct {
+		*response
+		Rates map[string]Decimal `json:"rates"`
+	}{response: (*response)(r)}
+
+	err := json.Unmarshal(b, &aux)
+	if err != nil {
+		return err
+	}
+
+	r.Rates, r.ExactRates = floatRates(aux.Rates), aux.Rates
+
+	return nil
+}
+
+// UnmarshalJSON decodes the response, decoding the rates of each day exactly into ExactRates as well as into Rates.
+func (r *TimeSeriesResponse) UnmarshalJSON(b []byte) error {
+	type response TimeSeriesResponse
+
+	aux := struct {
+		*response
+		Rates map[string]map[string]Decimal `json:"rates"`
+	}{response: (*response)(r)}
+
+	err := json.Unmarshal(b, &aux)
+	if err != nil {
+		return err
+	}
+
+	r.Rates, r.ExactRates = nil, aux.Rates
+	if aux.Rates != nil {
+		r.Rates = make(map[string]map[string]float64, len(aux.Rates))
+		for day, rates := range aux.Rates {
+			r.Rates[day] = floatRates(rates)
+		}
+	}
+
+	return nil
+}
+
+// UnmarshalJSON decodes the response, decoding the rates exactly into ExactRates as well as into Rates.
+func (r *OHLCResponse) UnmarshalJSON(b []byte) error {
+	type response OHLCResponse
+
+	aux := struct {
+		*response
+		Rates map[string]OHLCExactRate `json:"rates"`
+	}{response: (*response)(r)}
+
+	err := json.Unmarshal(b, &aux)
+	if err != nil {
+		return err
+	}
+
+	r.Rates, r.ExactRates = nil, aux.Rates
+	if aux.Rates != nil {
+		r.Rates = make(map[string]OHLCRate, len(aux.Rates))
+		for currency, rate := range aux.Rates {
+			r.Rates[currency] = OHLCRate{
+				Open:    rate.Open.Float64(),
+				High:    rate.High.Float64(),
+				Low:     rate.Low.Float64(),
+				Close:   rate.Close.Float64(),
+				Average: rate.Average.Float64(),
+			}
+		}
+	}
+
+	return nil
+}
+
+// UnmarshalJSON decodes the response, decoding the converted value exactly into ExactResponse as well as into Response.
+func (r *ConversionResponse) UnmarshalJSON(b []byte) error {
+	type response ConversionResponse
+
+	aux := struct {
+		*response
+		Response Decimal `json:"response"`
+	}{response: (*response)(r)}
+
+	err := json.Unmarshal(b, &aux)
+	if err != nil {
+		return err
+	}
+
+	r.Response, r.ExactResponse = aux.Response.Float64(), aux.Response
+
+	return nil
+}
+
+// UnmarshalJSON decodes the meta, decoding the rate exactly into ExactRate as well as into Rate.
+func (m *ConversionMeta) UnmarshalJSON(b []byte) error {
+	type meta ConversionMeta
+
+	aux := struct {
+		*meta
+		Rate Decimal `json:"rate"`
+	}{meta: (*meta)(m)}
+
+	err := json.Unmarshal(b, &aux)
+	if err != nil {
+		return err
+	}
+
+	m.Rate, m.ExactRate = aux.Rate.Float64(), aux.Rate
+
+	return nil
+}
+
+func floatRates(rates map[string]Decimal) map[string]float64 {
+	if rates == nil {
+		return nil
+	}
+
+	floats := make(map[string]float64, len(rates))
+	for currency, rate := range rates {
+		floats[currency] = rate.Float64()
+	}
+
+	return floats
+}
+
+// exactRates returns the exact rates, or those converted from the float rates when no exact rates are available.
+func exactRates(exact map[string]Decimal, rates map[string]float64) map[string]Decimal {
+	if exact != nil {
+		return exact
+	}
+
+	converted := make(map[string]Decimal, len(rates))
+	for currency, rate := range rates {
+		d, err := decimalFromFloat(rate)
+		if err == nil {
+			converted[currency] = d
+		}
+	}
+
+	return converted
+}
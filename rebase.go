@@ -0,0 +1,137 @@
+package oxr
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// rebasePlaces is the number of decimal places to which exact rates are derived.
+const rebasePlaces = 18
+
+// ErrMissingRate is returned when rates cannot be derived because a required rate is not available.
+var ErrMissingRate = errors.New("rate is not available")
+
+// Rebase derives the rates for another base currency from those of the response. The derived response is marked as
+// Derived.
+func (r LatestRatesResponse) Rebase(base string) (LatestRatesResponse, error) {
+	rates, err := rebase(r.Rates, r.Base, base)
+	if err != nil {
+		return LatestRatesResponse{}, err
+	}
+
+	exact, err := rebaseExact(r.ExactRates, r.Base, base)
+	if err != nil {
+		return LatestRatesResponse{}, err
+	}
+
+	r.Base = strings.ToUpper(base)
+	r.Rates = rates
+	r.ExactRates = exact
+	r.Derived = true
+
+	return r, nil
+}
+
+// Rebase derives the rates for another base currency from those of the response. The derived response is marked as
+// Derived.
+func (r HistoricalRatesResponse) Rebase(base string) (HistoricalRatesResponse, error) {
+	rates, err := rebase(r.Rates, r.Base, base)
+	if err != nil {
+		return HistoricalRatesResponse{}, err
+	}
+
+	exact, err := rebaseExact(r.ExactRates, r.Base, base)
+	if err != nil {
+		return HistoricalRatesResponse{}, err
+	}
+
+	r.Base = strings.ToUpper(base)
+	r.Rates = rates
+	r.ExactRates = exact
+	r.Derived = true
+
+	return r, nil
+}
+
+// rebase divides every rate by the rate of the new base, so that the new base has a rate of one.
+func rebase(rates map[string]float64, from, to string) (map[string]float64, error) {
+	from, to = strings.ToUpper(from), strings.ToUpper(to)
+	if from == "" {
+		from = "USD"
+	}
+
+	divisor, ok := rates[to]
+	if from == to {
+		divisor, ok = 1, true
+	}
+	if !ok || divisor <= 0 {
+		return nil, fmt.Errorf("rebasing from %s to %s: %s: %w", from, to, to, ErrMissingRate)
+	}
+
+	rebased := make(map[string]float64, len(rates)+1)
+	rebased[from] = 1 / divisor
+	for currency, rate := range rates {
+		rebased[currency] = rate / divisor
+	}
+	rebased[to] = 1
+
+	return rebased, nil
+}
+
+// rebaseExact divides every exact rate by the rate of the new base, rounding to rebasePlaces. Should there be no exact
+// rates, none are derived.
+func rebaseExact(rates map[string]Decimal, from, to string) (map[string]Decimal, error) {
+	if rates == nil {
+		return nil, nil
+	}
+
+	from, to = strings.ToUpper(from), strings.ToUpper(to)
+	if from == "" {
+		from = "USD"
+	}
+
+	divisor, ok := rates[to]
+	if from == to {
+		divisor, ok = NewDecimal(1, 0), true
+	}
+	if !ok || divisor.Sign() <= 0 {
+		return nil, fmt.Errorf("rebasing from %s to %s: %s: %w", from, to, to, ErrMissingRate)
+	}
+
+	one := NewDecimal(1, 0)
+
+	rebased := make(map[string]Decimal, len(rates)+1)
+	rebased[from], _ = one.Div(divisor, rebasePlaces, RoundHalfEven)
+	for currency, rate := range rates {
+		rebased[currency], _ = rate.Div(divisor, rebasePlaces, RoundHalfEven)
+	}
+	rebased[to] = one
+
+	return rebased, nil
+}
+
+// latestRebased retrieves the latest rates in US Dollars and derives those for the requested base, for plans which do
+// not support changing the base currency.
+func (c Client) latestRebased(ctx context.Context, r latestParams) (LatestRatesResponse, error) {
+	usd := r
+	usd.baseCurrency = ""
+	if r.destinationCurrencies != "" {
+		usd.destinationCurrencies = r.destinationCurrencies + "," + r.baseCurrency
+	}
+
+	res, err := c.latest(ctx, usd)
+	if err != nil {
+		return LatestRatesResponse{}, err
+	}
+
+	res, err = res.Rebase(r.baseCurrency)
+	if err != nil {
+		return LatestRatesResponse{}, err
+	}
+
+	r.meta.derive()
+
+	return onlySymbols(res, r.destinationCurrencies), nil
+}
@@ -0,0 +1,138 @@
+package oxr_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/jamieaitken/oxr"
+)
+
+func TestLatestRatesResponse_Rebase(t *testing.T) {
+	tests := []struct {
+		name        string
+		given       oxr.LatestRatesResponse
+		givenBase   string
+		expected    oxr.LatestRatesResponse
+		expectedErr error
+	}{
+		{
+			name: "given USD rates, expect rates derived for new base",
+			given: oxr.LatestRatesResponse{
+				Timestamp: 1647453600,
+				Base:      "USD",
+				Rates:     map[string]float64{"USD": 1, "GBP": 0.8, "EUR": 0.9},
+			},
+			givenBase: "gbp",
+			expected: oxr.LatestRatesResponse{
+				Timestamp: 1647453600,
+				Base:      "GBP",
+				Rates:     map[string]float64{"USD": 1.25, "GBP": 1, "EUR": 1.125},
+				Derived:   true,
+			},
+		},
+		{
+			name: "given rates without their base, expect base included",
+			given: oxr.LatestRatesResponse{
+				Base:  "USD",
+				Rates: map[string]float64{"GBP": 0.8},
+			},
+			givenBase: "GBP",
+			expected: oxr.LatestRatesResponse{
+				Base:    "GBP",
+				Rates:   map[string]float64{"USD": 1.25, "GBP": 1},
+				Derived: true,
+			},
+		},
+		{
+			name: "given new base without rate, expect error",
+			given: oxr.LatestRatesResponse{
+				Base:  "USD",
+				Rates: map[string]float64{"GBP": 0.8},
+			},
+			givenBase:   "JPY",
+			expectedErr: oxr.ErrMissingRate,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			actual, err := test.given.Rebase(test.givenBase)
+			if !errors.Is(err, test.expectedErr) {
+				t.Fatalf("expected %v, got %v", test.expectedErr, err)
+			}
+
+			if !cmp.Equal(actual, test.expected, cmpopts.EquateApprox(0, 1e-12)) {
+				t.Fatal(cmp.Diff(actual, test.expected))
+			}
+		})
+	}
+}
+
+func TestHistoricalRatesResponse_Rebase(t *testing.T) {
+	given := oxr.HistoricalRatesResponse{
+		Timestamp: 1647453600,
+		Base:      "USD",
+		Rates:     map[string]float64{"USD": 1, "GBP": 0.8, "EUR": 0.9},
+	}
+
+	actual, err := given.Rebase("EUR")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := oxr.HistoricalRatesResponse{
+		Timestamp: 1647453600,
+		Base:      "EUR",
+		Rates:     map[string]float64{"USD": 1 / 0.9, "GBP": 0.8 / 0.9, "EUR": 1},
+		Derived:   true,
+	}
+	if !cmp.Equal(actual, expected, cmpopts.EquateApprox(0, 1e-12)) {
+		t.Fatal(cmp.Diff(actual, expected))
+	}
+}
+
+func TestWithLocalRebase(t *testing.T) {
+	var actualQuery url.Values
+
+	doer := oxr.DoerFunc(func(r *http.Request) (*http.Response, error) {
+		body := freePlanUsage()
+		if !strings.HasSuffix(r.URL.Path, "usage.json") {
+			actualQuery = r.URL.Query()
+			body = successfulLatest()
+		}
+
+		return (&sequenceDoer{GivenResults: []mockResult{{StatusCode: http.StatusOK, Body: body}}}).Do(r)
+	})
+
+	c := oxr.New(oxr.WithAppID("test"), oxr.WithDoer(doer), oxr.WithLocalRebase())
+
+	actual, err := c.Latest(context.Background(),
+		oxr.LatestForBaseCurrency("GBP"),
+		oxr.LatestForDestinationCurrencies([]string{"USD"}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if actualQuery.Get("base") != "" || actualQuery.Get("symbols") != "USD,GBP" {
+		t.Fatalf("expected US Dollar rates to be requested, got %v", actualQuery)
+	}
+
+	expected := oxr.LatestRatesResponse{
+		Disclaimer: "Usage subject to terms: https://openexchangerates.org/terms",
+		License:    "https://openexchangerates.org/license",
+		Timestamp:  1647453600,
+		Base:       "GBP",
+		Rates:      map[string]float64{"USD": 1 / 0.764018},
+		ExactRates: map[string]oxr.Decimal{"USD": oxr.MustParseDecimal("1.308869686316290977")},
+		Derived:    true,
+	}
+	if !cmp.Equal(actual, expected, cmpopts.EquateApprox(0, 1e-12)) {
+		t.Fatal(cmp.Diff(actual, expected))
+	}
+}
@@ -0,0 +1,193 @@
+package oxr
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"math"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	defaultRetryMaxAttempts = 3
+	defaultRetryBaseDelay   = 100 * time.Millisecond
+	defaultRetryMaxDelay    = 10 * time.Second
+)
+
+// RetryPredicate decides whether the outcome of an attempt should be retried.
+type RetryPredicate func(res *http.Response, err error) bool
+
+// RetryDoer is a Doer which retries idempotent requests using capped exponential backoff with full jitter.
+type RetryDoer struct {
+	doer        Doer
+	maxAttempts int
+	baseDelay   time.Duration
+	maxDelay    time.Duration
+	shouldRetry RetryPredicate
+}
+
+// RetryOption allows a RetryDoer to be modified.
+type RetryOption func(*RetryDoer)
+
+// NewRetryDoer wraps the given Doer so that failed requests are retried.
+func NewRetryDoer(doer Doer, opts ...RetryOption) RetryDoer {
+	r := RetryDoer{
+		doer:        doer,
+		maxAttempts: defaultRetryMaxAttempts,
+		baseDelay:   defaultRetryBaseDelay,
+		maxDelay:    defaultRetryMaxDelay,
+		shouldRetry: DefaultRetryPredicate,
+	}
+
+	for _, opt := range opts {
+		opt(&r)
+	}
+
+	return r
+}
+
+// RetryWithMaxAttempts sets the maximum number of attempts, including the first, made for a request.
+func RetryWithMaxAttempts(attempts int) RetryOption {
+	return func(r *RetryDoer) {
+		r.maxAttempts = attempts
+	}
+}
+
+// RetryWithBaseDelay sets the delay used to calculate the backoff before the first retry.
+func RetryWithBaseDelay(delay time.Duration) RetryOption {
+	return func(r *RetryDoer) {
+		r.baseDelay = delay
+	}
+}
+
+// RetryWithMaxDelay sets the upper bound of the delay between attempts, including any asked for by Retry-After.
+func RetryWithMaxDelay(delay time.Duration) RetryOption {
+	return func(r *RetryDoer) {
+		r.maxDelay = delay
+	}
+}
+
+// RetryWithPredicate sets the RetryPredicate used to decide whether an attempt should be retried.
+func RetryWithPredicate(predicate RetryPredicate) RetryOption {
+	return func(r *RetryDoer) {
+		r.shouldRetry = predicate
+	}
+}
+
+// DefaultRetryPredicate retries transport errors, 429 Too Many Requests and 5xx responses. A 429 reporting that the
+// quota of the App ID has been exceeded is not retried, as it cannot succeed until the billing period resets.
+func DefaultRetryPredicate(res *http.Response, err error) bool {
+	if err != nil {
+		return true
+	}
+
+	if res.StatusCode == http.StatusTooManyRequests {
+		return !quotaExceeded(res)
+	}
+
+	return res.StatusCode >= http.StatusInternalServerError
+}
+
+// quotaExceeded reports whether the response is OXR's error for an exceeded quota. The body is read to find out, so it
+// is replaced with a copy for the response to be read again.
+func quotaExceeded(res *http.Response) bool {
+	if res.Body == nil {
+		return false
+	}
+
+	body, err := io.ReadAll(res.Body)
+	res.Body.Close()
+	res.Body = io.NopCloser(bytes.NewReader(body))
+	if err != nil {
+		return false
+	}
+
+	return errors.Is(newAPIError(res.StatusCode, body), ErrQuotaExceeded)
+}
+
+// Do implements Doer for RetryDoer. Only idempotent requests are retried and retrying stops as soon as the request
+// context is done. Should the Retry-After header ask for a longer wait than the maximum delay, the response is returned
+// rather than retried.
+func (r RetryDoer) Do(req *http.Request) (*http.Response, error) {
+	if !isIdempotent(req) {
+		return r.doer.Do(req)
+	}
+
+	for attempt := 1; ; attempt++ {
+		res, err := r.doer.Do(req)
+		if attempt >= r.maxAttempts || !r.shouldRetry(res, err) {
+			return res, err
+		}
+
+		delay := r.backoff(attempt)
+		if wait, ok := retryAfter(res); ok {
+			if wait > r.maxDelay {
+				return res, err
+			}
+
+			delay = wait
+		}
+
+		if res != nil {
+			_, _ = io.Copy(io.Discard, res.Body)
+			res.Body.Close()
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// backoff returns a random delay between zero and the capped exponential backoff for the given attempt.
+func (r RetryDoer) backoff(attempt int) time.Duration {
+	ceiling := float64(r.baseDelay) * math.Pow(2, float64(attempt-1))
+	if ceiling > float64(r.maxDelay) {
+		ceiling = float64(r.maxDelay)
+	}
+
+	return time.Duration(rand.Float64() * ceiling)
+}
+
+func isIdempotent(req *http.Request) bool {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return req.Body == nil || req.Body == http.NoBody
+	}
+
+	return false
+}
+
+// retryAfter parses the Retry-After header, which may either be a number of seconds or a HTTP date.
+func retryAfter(res *http.Response) (time.Duration, bool) {
+	if res == nil {
+		return 0, false
+	}
+
+	header := res.Header.Get("Retry-After")
+	if header == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(header); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if date, err := http.ParseTime(header); err == nil {
+		wait := time.Until(date)
+		if wait < 0 {
+			wait = 0
+		}
+
+		return wait, true
+	}
+
+	return 0, false
+}
@@ -0,0 +1,262 @@
+package oxr_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/jamieaitken/oxr"
+)
+
+func TestRetryDoer_Do(t *testing.T) {
+	tests := []struct {
+		name               string
+		givenMethod        string
+		givenResults       []mockResult
+		givenOpts          []oxr.RetryOption
+		expectedStatusCode int
+		expectedBody       string
+		expectedError      error
+		expectedCalls      int
+	}{
+		{
+			name:        "given 503 then 200, expect success after retry",
+			givenMethod: http.MethodGet,
+			givenResults: []mockResult{
+				{StatusCode: http.StatusServiceUnavailable},
+				{StatusCode: http.StatusOK},
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedCalls:      2,
+		},
+		{
+			name:        "given transport error then 200, expect success after retry",
+			givenMethod: http.MethodGet,
+			givenResults: []mockResult{
+				{Error: http.ErrHandlerTimeout},
+				{StatusCode: http.StatusOK},
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedCalls:      2,
+		},
+		{
+			name:        "given persistent 429, expect last response after max attempts",
+			givenMethod: http.MethodGet,
+			givenResults: []mockResult{
+				{StatusCode: http.StatusTooManyRequests},
+				{StatusCode: http.StatusTooManyRequests},
+				{StatusCode: http.StatusTooManyRequests},
+				{StatusCode: http.StatusOK},
+			},
+			givenOpts:          []oxr.RetryOption{oxr.RetryWithMaxAttempts(3)},
+			expectedStatusCode: http.StatusTooManyRequests,
+			expectedCalls:      3,
+		},
+		{
+			name:        "given 401, expect no retry",
+			givenMethod: http.MethodGet,
+			givenResults: []mockResult{
+				{StatusCode: http.StatusUnauthorized},
+				{StatusCode: http.StatusOK},
+			},
+			expectedStatusCode: http.StatusUnauthorized,
+			expectedCalls:      1,
+		},
+		{
+			name:        "given non idempotent request, expect no retry",
+			givenMethod: http.MethodPost,
+			givenResults: []mockResult{
+				{StatusCode: http.StatusServiceUnavailable},
+				{StatusCode: http.StatusOK},
+			},
+			expectedStatusCode: http.StatusServiceUnavailable,
+			expectedCalls:      1,
+		},
+		{
+			name:        "given retry after header, expect header to take precedence over backoff",
+			givenMethod: http.MethodGet,
+			givenResults: []mockResult{
+				{StatusCode: http.StatusTooManyRequests, Header: http.Header{"Retry-After": []string{"0"}}},
+				{StatusCode: http.StatusOK},
+			},
+			givenOpts: []oxr.RetryOption{
+				oxr.RetryWithBaseDelay(time.Hour),
+				oxr.RetryWithMaxDelay(time.Hour),
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedCalls:      2,
+		},
+		{
+			name:        "given retry after header beyond max delay, expect response returned",
+			givenMethod: http.MethodGet,
+			givenResults: []mockResult{
+				{StatusCode: http.StatusTooManyRequests, Header: http.Header{"Retry-After": []string{"3600"}}},
+				{StatusCode: http.StatusOK},
+			},
+			expectedStatusCode: http.StatusTooManyRequests,
+			expectedCalls:      1,
+		},
+		{
+			name:        "given quota exceeded, expect no retry and body preserved",
+			givenMethod: http.MethodGet,
+			givenResults: []mockResult{
+				{
+					StatusCode: http.StatusTooManyRequests,
+					Body:       errorPayload(http.StatusTooManyRequests, "access_restricted", "Access restricted for repeated over-use."),
+				},
+				{StatusCode: http.StatusOK},
+			},
+			expectedStatusCode: http.StatusTooManyRequests,
+			expectedBody:       errorPayload(http.StatusTooManyRequests, "access_restricted", "Access restricted for repeated over-use."),
+			expectedCalls:      1,
+		},
+		{
+			name:        "given custom predicate, expect predicate to decide what is retried",
+			givenMethod: http.MethodGet,
+			givenResults: []mockResult{
+				{StatusCode: http.StatusNotFound},
+				{StatusCode: http.StatusOK},
+			},
+			givenOpts: []oxr.RetryOption{
+				oxr.RetryWithPredicate(func(res *http.Response, err error) bool {
+					return err == nil && res.StatusCode == http.StatusNotFound
+				}),
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedCalls:      2,
+		},
+		{
+			name:        "given transport errors exhaust attempts, expect error returned",
+			givenMethod: http.MethodGet,
+			givenResults: []mockResult{
+				{Error: http.ErrHandlerTimeout},
+				{Error: http.ErrHandlerTimeout},
+			},
+			givenOpts:     []oxr.RetryOption{oxr.RetryWithMaxAttempts(2)},
+			expectedError: http.ErrHandlerTimeout,
+			expectedCalls: 2,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			doer := &sequenceDoer{GivenResults: test.givenResults}
+			opts := append([]oxr.RetryOption{
+				oxr.RetryWithBaseDelay(time.Millisecond),
+				oxr.RetryWithMaxDelay(time.Millisecond),
+			}, test.givenOpts...)
+
+			req, err := http.NewRequestWithContext(context.Background(), test.givenMethod, "https://openexchangerates.org/api/latest.json", http.NoBody)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			res, err := oxr.NewRetryDoer(doer, opts...).Do(req)
+			if !cmp.Equal(err, test.expectedError, cmpopts.EquateErrors()) {
+				t.Fatal(cmp.Diff(err, test.expectedError, cmpopts.EquateErrors()))
+			}
+
+			if err == nil && !cmp.Equal(res.StatusCode, test.expectedStatusCode) {
+				t.Fatal(cmp.Diff(res.StatusCode, test.expectedStatusCode))
+			}
+
+			if test.expectedBody != "" {
+				body, err := io.ReadAll(res.Body)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				if !cmp.Equal(string(body), test.expectedBody) {
+					t.Fatal(cmp.Diff(string(body), test.expectedBody))
+				}
+			}
+
+			if !cmp.Equal(doer.Calls(), test.expectedCalls) {
+				t.Fatal(cmp.Diff(doer.Calls(), test.expectedCalls))
+			}
+		})
+	}
+}
+
+func TestRetryDoer_Do_ContextDone(t *testing.T) {
+	doer := &sequenceDoer{GivenResults: []mockResult{
+		{StatusCode: http.StatusServiceUnavailable},
+		{StatusCode: http.StatusOK},
+	}}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "https://openexchangerates.org/api/latest.json", http.NoBody)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = oxr.NewRetryDoer(doer, oxr.RetryWithBaseDelay(time.Hour), oxr.RetryWithMaxDelay(time.Hour)).Do(req)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected %v, got %v", context.DeadlineExceeded, err)
+	}
+
+	if !cmp.Equal(doer.Calls(), 1) {
+		t.Fatal(cmp.Diff(doer.Calls(), 1))
+	}
+}
+
+type mockResult struct {
+	StatusCode int
+	Header     http.Header
+	Body       string
+	Error      error
+}
+
+// sequenceDoer returns each of GivenResults in turn, repeating the last once exhausted.
+type sequenceDoer struct {
+	GivenResults []mockResult
+	SpyURLs      []string
+	SpyHeaders   []http.Header
+
+	mu sync.Mutex
+}
+
+func (s *sequenceDoer) Do(r *http.Request) (*http.Response, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	result := s.GivenResults[len(s.GivenResults)-1]
+	if len(s.SpyURLs) < len(s.GivenResults) {
+		result = s.GivenResults[len(s.SpyURLs)]
+	}
+
+	s.SpyURLs = append(s.SpyURLs, r.URL.String())
+	s.SpyHeaders = append(s.SpyHeaders, r.Header.Clone())
+
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	header := result.Header
+	if header == nil {
+		header = http.Header{}
+	}
+
+	return &http.Response{
+		Status:     http.StatusText(result.StatusCode),
+		StatusCode: result.StatusCode,
+		Header:     header,
+		Body:       io.NopCloser(strings.NewReader(result.Body)),
+		Request:    r,
+	}, nil
+}
+
+func (s *sequenceDoer) Calls() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return len(s.SpyURLs)
+}
@@ -0,0 +1,130 @@
+package oxr
+
+import (
+	"math/big"
+	"strings"
+)
+
+// defaultMinorUnits is the number of decimal places used for currencies with unknown minor units.
+const defaultMinorUnits = 2
+
+// RoundingPolicy decides how a number is rounded to fewer decimal places.
+type RoundingPolicy int
+
+// Available rounding policies.
+const (
+	// RoundHalfUp rounds to the nearest number, with halves rounded away from zero.
+	RoundHalfUp RoundingPolicy = iota
+	// RoundHalfEven rounds to the nearest number, with halves rounded to the even neighbour. Also known as banker's
+	// rounding.
+	RoundHalfEven
+	// RoundDown rounds towards zero, truncating the number.
+	RoundDown
+	// RoundUp rounds away from zero.
+	RoundUp
+)
+
+// MinorUnits returns the number of decimal places of the currency's minor unit, for example 2 for GBP and 0 for JPY.
+// The boolean is false should the currency be unknown, or have no minor unit.
+func MinorUnits(currency string) (int32, bool) {
+	info, ok := Currency(strings.ToUpper(currency)).Info()
+	if !ok || info.MinorUnits < 0 {
+		return 0, false
+	}
+
+	return info.MinorUnits, true
+}
+
+// CurrencyRounding is how amounts of a currency are rounded.
+type CurrencyRounding struct {
+	Places int32
+	Policy RoundingPolicy
+}
+
+// Rounding decides how amounts of each currency are rounded. Amounts are rounded to the minor units of their currency
+// using a single RoundingPolicy, unless overridden for the currency. The zero value rounds half up.
+type Rounding struct {
+	policy    RoundingPolicy
+	overrides map[string]CurrencyRounding
+}
+
+// RoundingOption allows Rounding to be modified.
+type RoundingOption func(*Rounding)
+
+// NewRounding instantiates Rounding.
+func NewRounding(opts ...RoundingOption) Rounding {
+	r := Rounding{
+		policy:    RoundHalfUp,
+		overrides: make(map[string]CurrencyRounding),
+	}
+
+	for _, opt := range opts {
+		opt(&r)
+	}
+
+	return r
+}
+
+// RoundingWithPolicy sets the RoundingPolicy used for currencies which are not overridden. Defaults to RoundHalfUp.
+func RoundingWithPolicy(policy RoundingPolicy) RoundingOption {
+	return func(r *Rounding) {
+		r.policy = policy
+	}
+}
+
+// RoundingForCurrency overrides the number of decimal places and RoundingPolicy used for the currency.
+func RoundingForCurrency(currency string, places int32, policy RoundingPolicy) RoundingOption {
+	return func(r *Rounding) {
+		r.overrides[strings.ToUpper(currency)] = CurrencyRounding{Places: places, Policy: policy}
+	}
+}
+
+// For returns how amounts of the currency are rounded.
+func (r Rounding) For(currency string) CurrencyRounding {
+	currency = strings.ToUpper(currency)
+
+	if override, ok := r.overrides[currency]; ok {
+		return override
+	}
+
+	places, ok := MinorUnits(currency)
+	if !ok {
+		places = defaultMinorUnits
+	}
+
+	return CurrencyRounding{Places: places, Policy: r.policy}
+}
+
+// roundQuo returns num ÷ den rounded according to the policy.
+func roundQuo(num, den *big.Int, policy RoundingPolicy) *big.Int {
+	q, r := new(big.Int).QuoRem(num, den, new(big.Int))
+	if r.Sign() == 0 {
+		return q
+	}
+
+	twice := new(big.Int).Abs(r)
+	twice.Lsh(twice, 1)
+	half := twice.Cmp(new(big.Int).Abs(den))
+
+	var away bool
+	switch policy {
+	case RoundHalfUp:
+		away = half >= 0
+	case RoundHalfEven:
+		away = half > 0 || (half == 0 && q.Bit(0) == 1)
+	case RoundDown:
+		away = false
+	case RoundUp:
+		away = true
+	}
+
+	if !away {
+		return q
+	}
+
+	if num.Sign()*den.Sign() < 0 {
+		return q.Sub(q, big.NewInt(1))
+	}
+
+	return q.Add(q, big.NewInt(1))
+}
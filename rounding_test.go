@@ -0,0 +1,235 @@
+package oxr_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jamieaitken/oxr"
+)
+
+func TestDecimal_Round(t *testing.T) {
+	tests := []struct {
+		name     string
+		given    string
+		expected map[oxr.RoundingPolicy]string
+	}{
+		{
+			name:  "given below half",
+			given: "2.344",
+			expected: map[oxr.RoundingPolicy]string{
+				oxr.RoundHalfUp: "2.34", oxr.RoundHalfEven: "2.34", oxr.RoundDown: "2.34", oxr.RoundUp: "2.35",
+			},
+		},
+		{
+			name:  "given half with even neighbour below",
+			given: "2.345",
+			expected: map[oxr.RoundingPolicy]string{
+				oxr.RoundHalfUp: "2.35", oxr.RoundHalfEven: "2.34", oxr.RoundDown: "2.34", oxr.RoundUp: "2.35",
+			},
+		},
+		{
+			name:  "given half with even neighbour above",
+			given: "2.355",
+			expected: map[oxr.RoundingPolicy]string{
+				oxr.RoundHalfUp: "2.36", oxr.RoundHalfEven: "2.36", oxr.RoundDown: "2.35", oxr.RoundUp: "2.36",
+			},
+		},
+		{
+			name:  "given negative half",
+			given: "-2.345",
+			expected: map[oxr.RoundingPolicy]string{
+				oxr.RoundHalfUp: "-2.35", oxr.RoundHalfEven: "-2.34", oxr.RoundDown: "-2.34", oxr.RoundUp: "-2.35",
+			},
+		},
+		{
+			name:  "given fewer places, expect unchanged",
+			given: "2.3",
+			expected: map[oxr.RoundingPolicy]string{
+				oxr.RoundHalfUp: "2.3", oxr.RoundHalfEven: "2.3", oxr.RoundDown: "2.3", oxr.RoundUp: "2.3",
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			for policy, expected := range test.expected {
+				actual := oxr.MustParseDecimal(test.given).Round(2, policy).String()
+				if !cmp.Equal(actual, expected) {
+					t.Fatalf("policy %d: %s", policy, cmp.Diff(actual, expected))
+				}
+			}
+		})
+	}
+}
+
+func TestDecimal_Round_NegativePlaces(t *testing.T) {
+	tests := []struct {
+		name        string
+		given       string
+		givenPlaces int32
+		expected    string
+	}{
+		{
+			name:        "given hundreds, expect rounded to nearest hundred",
+			given:       "1234.5",
+			givenPlaces: -2,
+			expected:    "1200",
+		},
+		{
+			name:        "given half, expect rounded up",
+			given:       "-150",
+			givenPlaces: -2,
+			expected:    "-200",
+		},
+		{
+			name:        "given integer, expect unchanged",
+			given:       "1200",
+			givenPlaces: -2,
+			expected:    "1200",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			actual := oxr.MustParseDecimal(test.given).Round(test.givenPlaces, oxr.RoundHalfUp).String()
+			if !cmp.Equal(actual, test.expected) {
+				t.Fatal(cmp.Diff(actual, test.expected))
+			}
+		})
+	}
+}
+
+func TestMinorUnits(t *testing.T) {
+	tests := []struct {
+		given         string
+		expectedUnits int32
+		expectedOK    bool
+	}{
+		{given: "GBP", expectedUnits: 2, expectedOK: true},
+		{given: "jpy", expectedUnits: 0, expectedOK: true},
+		{given: "KWD", expectedUnits: 3, expectedOK: true},
+		{given: "CLF", expectedUnits: 4, expectedOK: true},
+		{given: "BTC", expectedUnits: 8, expectedOK: true},
+		{given: "XYZ", expectedUnits: 0, expectedOK: false},
+	}
+	for _, test := range tests {
+		t.Run(test.given, func(t *testing.T) {
+			actualUnits, actualOK := oxr.MinorUnits(test.given)
+
+			if !cmp.Equal(actualUnits, test.expectedUnits) {
+				t.Fatal(cmp.Diff(actualUnits, test.expectedUnits))
+			}
+
+			if !cmp.Equal(actualOK, test.expectedOK) {
+				t.Fatal(cmp.Diff(actualOK, test.expectedOK))
+			}
+		})
+	}
+}
+
+func TestRounding_For(t *testing.T) {
+	rounding := oxr.NewRounding(
+		oxr.RoundingWithPolicy(oxr.RoundHalfEven),
+		oxr.RoundingForCurrency("jpy", 2, oxr.RoundDown),
+	)
+
+	tests := []struct {
+		given    string
+		expected oxr.CurrencyRounding
+	}{
+		{given: "GBP", expected: oxr.CurrencyRounding{Places: 2, Policy: oxr.RoundHalfEven}},
+		{given: "KWD", expected: oxr.CurrencyRounding{Places: 3, Policy: oxr.RoundHalfEven}},
+		{given: "JPY", expected: oxr.CurrencyRounding{Places: 2, Policy: oxr.RoundDown}},
+		{given: "XYZ", expected: oxr.CurrencyRounding{Places: 2, Policy: oxr.RoundHalfEven}},
+	}
+	for _, test := range tests {
+		t.Run(test.given, func(t *testing.T) {
+			actual := rounding.For(test.given)
+			if !cmp.Equal(actual, test.expected) {
+				t.Fatal(cmp.Diff(actual, test.expected))
+			}
+		})
+	}
+}
+
+func TestMoney_Round(t *testing.T) {
+	tests := []struct {
+		name          string
+		givenMoney    oxr.Money
+		givenRounding oxr.Rounding
+		expected      string
+	}{
+		{
+			name:       "given zero rounding, expect half up to minor units",
+			givenMoney: mustParseMoney(t, "1234.5", "JPY"),
+			expected:   "1235 JPY",
+		},
+		{
+			name:          "given policy, expect it used",
+			givenMoney:    mustParseMoney(t, "1.0045", "KWD"),
+			givenRounding: oxr.NewRounding(oxr.RoundingWithPolicy(oxr.RoundHalfEven)),
+			expected:      "1.004 KWD",
+		},
+		{
+			name:          "given currency override, expect it used",
+			givenMoney:    mustParseMoney(t, "0.123456789", "BTC"),
+			givenRounding: oxr.NewRounding(oxr.RoundingForCurrency("BTC", 4, oxr.RoundUp)),
+			expected:      "0.1235 BTC",
+		},
+		{
+			name:          "given negative places, expect rounded to the left of the point",
+			givenMoney:    mustParseMoney(t, "1234.5", "JPY"),
+			givenRounding: oxr.NewRounding(oxr.RoundingForCurrency("JPY", -2, oxr.RoundHalfUp)),
+			expected:      "1200 JPY",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			actual := test.givenMoney.Round(test.givenRounding).String()
+			if !cmp.Equal(actual, test.expected) {
+				t.Fatal(cmp.Diff(actual, test.expected))
+			}
+		})
+	}
+}
+
+func TestConverter_Rounding(t *testing.T) {
+	snapshot := oxr.LatestRatesResponse{Base: "USD", Rates: map[string]float64{"JPY": 118.75}}
+
+	tests := []struct {
+		name      string
+		givenOpts []oxr.ConverterOption
+		expected  float64
+	}{
+		{
+			name:     "given no rounding, expect unrounded",
+			expected: 118.75,
+		},
+		{
+			name:      "given default rounding, expect minor units of destination",
+			givenOpts: []oxr.ConverterOption{oxr.ConverterWithRounding(oxr.NewRounding())},
+			expected:  119,
+		},
+		{
+			name: "given override, expect it used",
+			givenOpts: []oxr.ConverterOption{
+				oxr.ConverterWithRounding(oxr.NewRounding(oxr.RoundingForCurrency("JPY", 1, oxr.RoundHalfEven))),
+			},
+			expected: 118.8,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			actual, err := oxr.NewLatestConverter(snapshot, test.givenOpts...).Convert(
+				oxr.ConvertWithValue(1),
+				oxr.ConvertForBaseCurrency("USD"),
+				oxr.ConvertForDestinationCurrency("JPY"),
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !cmp.Equal(actual.Response, test.expected) {
+				t.Fatal(cmp.Diff(actual.Response, test.expected))
+			}
+		})
+	}
+}
@@ -12,6 +12,11 @@ type timeSeriesParams struct {
 	destinationCurrencies string
 	showAlternative       bool
 	prettyPrint           bool
+	meta                  *ResponseMeta
+
+	// currencyErr is the first currency given which is not in the registry, returned before any request is sent unless
+	// currency validation is disabled.
+	currencyErr error
 }
 
 // TimeSeriesOption allows the client to specify values for a TimeSeries request.
@@ -20,14 +25,16 @@ type TimeSeriesOption func(params *timeSeriesParams)
 // TimeSeriesForBaseCurrency sets the base currency to be used.
 func TimeSeriesForBaseCurrency(currency string) TimeSeriesOption {
 	return func(p *timeSeriesParams) {
-		p.baseCurrency = currency
+		code, err := validCurrency(currency)
+		p.baseCurrency, p.currencyErr = code, firstErr(p.currencyErr, err)
 	}
 }
 
 // TimeSeriesForDestinationCurrencies sets the destination currencies to be included in the response.
 func TimeSeriesForDestinationCurrencies(currencies []string) TimeSeriesOption {
 	return func(p *timeSeriesParams) {
-		p.destinationCurrencies = strings.Join(currencies, ",")
+		codes, err := validCurrencies(currencies)
+		p.destinationCurrencies, p.currencyErr = strings.Join(codes, ","), firstErr(p.currencyErr, err)
 	}
 }
 
@@ -58,3 +65,10 @@ func TimeSeriesWithPrettyPrint(active bool) TimeSeriesOption {
 		p.prettyPrint = active
 	}
 }
+
+// TimeSeriesWithMeta populates meta with how the result of the time series request was obtained.
+func TimeSeriesWithMeta(meta *ResponseMeta) TimeSeriesOption {
+	return func(p *timeSeriesParams) {
+		p.meta = meta
+	}
+}
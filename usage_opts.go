@@ -2,6 +2,7 @@ package oxr
 
 type usageParams struct {
 	prettyPrint bool
+	meta        *ResponseMeta
 }
 
 // UsageOption allows the client to specify values for a usage request.
@@ -13,3 +14,10 @@ func UsageWithPrettyPrint(active bool) UsageOption {
 		p.prettyPrint = active
 	}
 }
+
+// UsageWithMeta populates meta with how the result of the usage request was obtained.
+func UsageWithMeta(meta *ResponseMeta) UsageOption {
+	return func(p *usageParams) {
+		p.meta = meta
+	}
+}